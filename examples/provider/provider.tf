@@ -1,3 +1,11 @@
+terraform {
+  required_providers {
+    logflare = {
+      source = "supabase/supabase-analytics"
+    }
+  }
+}
+
 provider "logflare" {
   access_token = "my-cool-api-key-123"
   host         = "http://localhost:4000"
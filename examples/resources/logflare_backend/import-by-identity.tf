@@ -0,0 +1,6 @@
+import {
+  to = logflare_backend.example
+  identity = {
+    token = "backend-token"
+  }
+}
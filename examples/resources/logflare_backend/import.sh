@@ -0,0 +1 @@
+terraform import logflare_backend.example backend-token
@@ -0,0 +1,7 @@
+resource "logflare_backend" "example" {
+  name = "alerts-webhook"
+  type = "webhook"
+  config = jsonencode({
+    url = "https://example.com/logflare"
+  })
+}
@@ -0,0 +1,6 @@
+import {
+  to = logflare_endpoint.example
+  identity = {
+    token = "endpoint-token"
+  }
+}
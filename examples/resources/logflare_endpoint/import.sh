@@ -0,0 +1 @@
+terraform import logflare_endpoint.example endpoint-token
@@ -0,0 +1,6 @@
+import {
+  to = logflare_rule.example
+  identity = {
+    token = "rule-token"
+  }
+}
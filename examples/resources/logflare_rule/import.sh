@@ -0,0 +1 @@
+terraform import logflare_rule.example rule-token
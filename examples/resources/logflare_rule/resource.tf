@@ -0,0 +1,17 @@
+resource "logflare_source" "example" {
+  name = "my-cool-source"
+}
+
+resource "logflare_backend" "example" {
+  name = "alerts-webhook"
+  type = "webhook"
+  config = jsonencode({
+    url = "https://example.com/logflare"
+  })
+}
+
+resource "logflare_rule" "example" {
+  source_id  = logflare_source.example.id
+  backend_id = logflare_backend.example.id
+  lql_string = "m.status_code:>499"
+}
@@ -0,0 +1,6 @@
+import {
+  to = logflare_source.example
+  identity = {
+    token = "source-token"
+  }
+}
@@ -0,0 +1 @@
+terraform import logflare_source.example source-token
@@ -0,0 +1,15 @@
+resource "logflare_source" "example" {
+  name = "my-cool-source"
+}
+
+resource "logflare_source_schema_seed" "example" {
+  source_token = logflare_source.example.token
+  events = [
+    jsonencode({
+      event_message = "user signed in"
+      metadata = {
+        user_id = "123"
+      }
+    }),
+  ]
+}
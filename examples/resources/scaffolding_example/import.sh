@@ -1 +1 @@
-terraform import logflare_endpoint.example 123
+terraform import logflare_endpoint.example 1d3f0b4e-3c5a-4b8e-9f2a-7c6d5e4b3a21
@@ -17,6 +17,14 @@ import (
 	"github.com/oapi-codegen/runtime"
 )
 
+// Defines values for EndpointApiSchemaLanguage.
+const (
+	BqSql EndpointApiSchemaLanguage = "bq_sql"
+	ChSql EndpointApiSchemaLanguage = "ch_sql"
+	Lql   EndpointApiSchemaLanguage = "lql"
+	PgSql EndpointApiSchemaLanguage = "pg_sql"
+)
+
 // AccessToken defines model for AccessToken.
 type AccessToken struct {
 	Description *string    `json:"description,omitempty"`
@@ -35,6 +43,7 @@ type BackendApiSchema struct {
 	Metadata      *map[string]interface{} `json:"metadata,omitempty"`
 	Name          string                  `json:"name"`
 	Token         *string                 `json:"token,omitempty"`
+	Type          *string                 `json:"type,omitempty"`
 	UpdatedAt     *time.Time              `json:"updated_at,omitempty"`
 }
 
@@ -43,18 +52,25 @@ type BadRequest = interface{}
 
 // EndpointApiSchema defines model for EndpointApiSchema.
 type EndpointApiSchema struct {
-	CacheDurationSeconds       *int                    `json:"cache_duration_seconds,omitempty"`
-	Description                *string                 `json:"description"`
-	EnableAuth                 *bool                   `json:"enable_auth,omitempty"`
-	Id                         *int                    `json:"id,omitempty"`
-	MaxLimit                   *int                    `json:"max_limit,omitempty"`
-	Name                       string                  `json:"name"`
-	ProactiveRequeryingSeconds *int                    `json:"proactive_requerying_seconds,omitempty"`
-	Query                      string                  `json:"query"`
-	Sandboxable                *bool                   `json:"sandboxable"`
-	SourceMapping              *map[string]interface{} `json:"source_mapping"`
-	Token                      *string                 `json:"token,omitempty"`
-}
+	BackendId                  *int                       `json:"backend_id"`
+	CacheDurationSeconds       *int                       `json:"cache_duration_seconds,omitempty"`
+	Description                *string                    `json:"description"`
+	EnableAuth                 *bool                      `json:"enable_auth,omitempty"`
+	Id                         *int                       `json:"id,omitempty"`
+	Labels                     *string                    `json:"labels"`
+	Language                   *EndpointApiSchemaLanguage `json:"language,omitempty"`
+	MaxLimit                   *int                       `json:"max_limit,omitempty"`
+	Name                       string                     `json:"name"`
+	ProactiveRequeryingSeconds *int                       `json:"proactive_requerying_seconds,omitempty"`
+	Query                      string                     `json:"query"`
+	RedactPii                  *bool                      `json:"redact_pii,omitempty"`
+	Sandboxable                *bool                      `json:"sandboxable"`
+	SourceMapping              *map[string]interface{}    `json:"source_mapping"`
+	Token                      *string                    `json:"token,omitempty"`
+}
+
+// EndpointApiSchemaLanguage defines model for EndpointApiSchema.Language.
+type EndpointApiSchemaLanguage string
 
 // EndpointQuery defines model for EndpointQuery.
 type EndpointQuery struct {
@@ -79,6 +95,17 @@ type Event struct {
 	Timestamp    *int    `json:"timestamp,omitempty"`
 }
 
+// HealthCheck defines model for HealthCheck.
+type HealthCheck struct {
+	Status  *string `json:"status,omitempty"`
+	Version *string `json:"version,omitempty"`
+}
+
+// LogsBatch defines model for LogsBatch.
+type LogsBatch struct {
+	Batch *[]map[string]interface{} `json:"batch,omitempty"`
+}
+
 // LogsCreated defines model for LogsCreated.
 type LogsCreated struct {
 	Message *string `json:"message,omitempty"`
@@ -146,24 +173,42 @@ type ServerError = interface{}
 // Source defines model for Source.
 type Source struct {
 	ApiQuota                    *int                    `json:"api_quota,omitempty"`
+	Backends                    *[]BackendApiSchema     `json:"backends,omitempty"`
+	BigqueryClusteringFields    *string                 `json:"bigquery_clustering_fields,omitempty"`
 	BigqueryTableTtl            *int                    `json:"bigquery_table_ttl,omitempty"`
 	BqTableId                   *string                 `json:"bq_table_id,omitempty"`
 	CustomEventMessageKeys      *string                 `json:"custom_event_message_keys,omitempty"`
 	DefaultIngestBackendEnabled *bool                   `json:"default_ingest_backend_enabled?,omitempty"`
+	DropLqlString               *string                 `json:"drop_lql_string,omitempty"`
 	Favorite                    *bool                   `json:"favorite,omitempty"`
 	HasRejectedEvents           *bool                   `json:"has_rejected_events,omitempty"`
 	Id                          *int                    `json:"id,omitempty"`
 	InsertedAt                  *time.Time              `json:"inserted_at,omitempty"`
-	Metrics                     *map[string]interface{} `json:"metrics,omitempty"`
+	LockSchema                  *bool                   `json:"lock_schema,omitempty"`
+	Metrics                     *SourceMetrics          `json:"metrics,omitempty"`
 	Name                        string                  `json:"name"`
 	Notifications               *map[string]interface{} `json:"notifications,omitempty"`
 	PublicToken                 *string                 `json:"public_token,omitempty"`
+	RetentionDays               *int                    `json:"retention_days,omitempty"`
 	SlackHookUrl                *string                 `json:"slack_hook_url,omitempty"`
+	SuggestedKeys               *string                 `json:"suggested_keys,omitempty"`
 	Token                       *string                 `json:"token,omitempty"`
+	TransformCopyFields         *string                 `json:"transform_copy_fields,omitempty"`
 	UpdatedAt                   *time.Time              `json:"updated_at,omitempty"`
+	ValidateSchema              *bool                   `json:"validate_schema,omitempty"`
 	WebhookNotificationUrl      *string                 `json:"webhook_notification_url,omitempty"`
 }
 
+// SourceMetrics defines model for SourceMetrics.
+type SourceMetrics struct {
+	Avg     *float64 `json:"avg,omitempty"`
+	Buffer  *int     `json:"buffer,omitempty"`
+	Inserts *int     `json:"inserts,omitempty"`
+	Max     *int     `json:"max,omitempty"`
+	Rate    *int     `json:"rate,omitempty"`
+	Recent  *int     `json:"recent,omitempty"`
+}
+
 // SourceSchema defines model for SourceSchema.
 type SourceSchema = map[string]interface{}
 
@@ -286,6 +331,9 @@ type LogflareWebApiEndpointControllerUpdate2JSONRequestBody = EndpointApiSchema
 // LogflareWebApiEndpointControllerUpdateJSONRequestBody defines body for LogflareWebApiEndpointControllerUpdate for application/json ContentType.
 type LogflareWebApiEndpointControllerUpdateJSONRequestBody = EndpointApiSchema
 
+// LogflareWebLogControllerCreate4JSONRequestBody defines body for LogflareWebLogControllerCreate4 for application/json ContentType.
+type LogflareWebLogControllerCreate4JSONRequestBody = LogsBatch
+
 // LogflareWebApiRuleControllerCreateJSONRequestBody defines body for LogflareWebApiRuleControllerCreate for application/json ContentType.
 type LogflareWebApiRuleControllerCreateJSONRequestBody = RuleApiSchema
 
@@ -649,8 +697,10 @@ type ClientInterface interface {
 	// LogflareWebLogControllerCreate3 request
 	LogflareWebLogControllerCreate3(ctx context.Context, params *LogflareWebLogControllerCreate3Params, reqEditors ...RequestEditorFn) (*http.Response, error)
 
-	// LogflareWebLogControllerCreate4 request
-	LogflareWebLogControllerCreate4(ctx context.Context, params *LogflareWebLogControllerCreate4Params, reqEditors ...RequestEditorFn) (*http.Response, error)
+	// LogflareWebLogControllerCreate4WithBody request with any body
+	LogflareWebLogControllerCreate4WithBody(ctx context.Context, params *LogflareWebLogControllerCreate4Params, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	LogflareWebLogControllerCreate4(ctx context.Context, params *LogflareWebLogControllerCreate4Params, body LogflareWebLogControllerCreate4JSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// LogflareWebApiQueryControllerQuery request
 	LogflareWebApiQueryControllerQuery(ctx context.Context, params *LogflareWebApiQueryControllerQueryParams, reqEditors ...RequestEditorFn) (*http.Response, error)
@@ -741,6 +791,9 @@ type ClientInterface interface {
 	LogflareWebApiTeamControllerUpdateWithBody(ctx context.Context, token string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	LogflareWebApiTeamControllerUpdate(ctx context.Context, token string, body LogflareWebApiTeamControllerUpdateJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// LogflareWebHealthCheckControllerCheck request
+	LogflareWebHealthCheckControllerCheck(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
 func (c *Client) LogflareWebApiAccessTokenControllerIndex(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -1079,8 +1132,20 @@ func (c *Client) LogflareWebLogControllerCreate3(ctx context.Context, params *Lo
 	return c.Client.Do(req)
 }
 
-func (c *Client) LogflareWebLogControllerCreate4(ctx context.Context, params *LogflareWebLogControllerCreate4Params, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewLogflareWebLogControllerCreate4Request(c.Server, params)
+func (c *Client) LogflareWebLogControllerCreate4WithBody(ctx context.Context, params *LogflareWebLogControllerCreate4Params, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewLogflareWebLogControllerCreate4RequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) LogflareWebLogControllerCreate4(ctx context.Context, params *LogflareWebLogControllerCreate4Params, body LogflareWebLogControllerCreate4JSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewLogflareWebLogControllerCreate4Request(c.Server, params, body)
 	if err != nil {
 		return nil, err
 	}
@@ -1487,6 +1552,18 @@ func (c *Client) LogflareWebApiTeamControllerUpdate(ctx context.Context, token s
 	return c.Client.Do(req)
 }
 
+func (c *Client) LogflareWebHealthCheckControllerCheck(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewLogflareWebHealthCheckControllerCheckRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 // NewLogflareWebApiAccessTokenControllerIndexRequest generates requests for LogflareWebApiAccessTokenControllerIndex
 func NewLogflareWebApiAccessTokenControllerIndexRequest(server string) (*http.Request, error) {
 	var err error
@@ -2343,8 +2420,19 @@ func NewLogflareWebLogControllerCreate3Request(server string, params *LogflareWe
 	return req, nil
 }
 
-// NewLogflareWebLogControllerCreate4Request generates requests for LogflareWebLogControllerCreate4
-func NewLogflareWebLogControllerCreate4Request(server string, params *LogflareWebLogControllerCreate4Params) (*http.Request, error) {
+// NewLogflareWebLogControllerCreate4Request calls the generic LogflareWebLogControllerCreate4 builder with application/json body
+func NewLogflareWebLogControllerCreate4Request(server string, params *LogflareWebLogControllerCreate4Params, body LogflareWebLogControllerCreate4JSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewLogflareWebLogControllerCreate4RequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewLogflareWebLogControllerCreate4RequestWithBody generates requests for LogflareWebLogControllerCreate4 with any type of body
+func NewLogflareWebLogControllerCreate4RequestWithBody(server string, params *LogflareWebLogControllerCreate4Params, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -2400,11 +2488,13 @@ func NewLogflareWebLogControllerCreate4Request(server string, params *LogflareWe
 		queryURL.RawQuery = queryValues.Encode()
 	}
 
-	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	req, err := http.NewRequest("POST", queryURL.String(), body)
 	if err != nil {
 		return nil, err
 	}
 
+	req.Header.Add("Content-Type", contentType)
+
 	return req, nil
 }
 
@@ -3423,6 +3513,33 @@ func NewLogflareWebApiTeamControllerUpdateRequestWithBody(server string, token s
 	return req, nil
 }
 
+// NewLogflareWebHealthCheckControllerCheckRequest generates requests for LogflareWebHealthCheckControllerCheck
+func NewLogflareWebHealthCheckControllerCheckRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/health")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
 	for _, r := range c.RequestEditors {
 		if err := r(ctx, req); err != nil {
@@ -3543,8 +3660,10 @@ type ClientWithResponsesInterface interface {
 	// LogflareWebLogControllerCreate3WithResponse request
 	LogflareWebLogControllerCreate3WithResponse(ctx context.Context, params *LogflareWebLogControllerCreate3Params, reqEditors ...RequestEditorFn) (*LogflareWebLogControllerCreate3Response, error)
 
-	// LogflareWebLogControllerCreate4WithResponse request
-	LogflareWebLogControllerCreate4WithResponse(ctx context.Context, params *LogflareWebLogControllerCreate4Params, reqEditors ...RequestEditorFn) (*LogflareWebLogControllerCreate4Response, error)
+	// LogflareWebLogControllerCreate4WithBodyWithResponse request with any body
+	LogflareWebLogControllerCreate4WithBodyWithResponse(ctx context.Context, params *LogflareWebLogControllerCreate4Params, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*LogflareWebLogControllerCreate4Response, error)
+
+	LogflareWebLogControllerCreate4WithResponse(ctx context.Context, params *LogflareWebLogControllerCreate4Params, body LogflareWebLogControllerCreate4JSONRequestBody, reqEditors ...RequestEditorFn) (*LogflareWebLogControllerCreate4Response, error)
 
 	// LogflareWebApiQueryControllerQueryWithResponse request
 	LogflareWebApiQueryControllerQueryWithResponse(ctx context.Context, params *LogflareWebApiQueryControllerQueryParams, reqEditors ...RequestEditorFn) (*LogflareWebApiQueryControllerQueryResponse, error)
@@ -3635,6 +3754,9 @@ type ClientWithResponsesInterface interface {
 	LogflareWebApiTeamControllerUpdateWithBodyWithResponse(ctx context.Context, token string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*LogflareWebApiTeamControllerUpdateResponse, error)
 
 	LogflareWebApiTeamControllerUpdateWithResponse(ctx context.Context, token string, body LogflareWebApiTeamControllerUpdateJSONRequestBody, reqEditors ...RequestEditorFn) (*LogflareWebApiTeamControllerUpdateResponse, error)
+
+	// LogflareWebHealthCheckControllerCheckWithResponse request
+	LogflareWebHealthCheckControllerCheckWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*LogflareWebHealthCheckControllerCheckResponse, error)
 }
 
 type LogflareWebApiAccessTokenControllerIndexResponse struct {
@@ -4659,6 +4781,28 @@ func (r LogflareWebApiTeamControllerUpdateResponse) StatusCode() int {
 	return 0
 }
 
+type LogflareWebHealthCheckControllerCheckResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *HealthCheck
+}
+
+// Status returns HTTPResponse.Status
+func (r LogflareWebHealthCheckControllerCheckResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r LogflareWebHealthCheckControllerCheckResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 // LogflareWebApiAccessTokenControllerIndexWithResponse request returning *LogflareWebApiAccessTokenControllerIndexResponse
 func (c *ClientWithResponses) LogflareWebApiAccessTokenControllerIndexWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*LogflareWebApiAccessTokenControllerIndexResponse, error) {
 	rsp, err := c.LogflareWebApiAccessTokenControllerIndex(ctx, reqEditors...)
@@ -4904,9 +5048,17 @@ func (c *ClientWithResponses) LogflareWebLogControllerCreate3WithResponse(ctx co
 	return ParseLogflareWebLogControllerCreate3Response(rsp)
 }
 
-// LogflareWebLogControllerCreate4WithResponse request returning *LogflareWebLogControllerCreate4Response
-func (c *ClientWithResponses) LogflareWebLogControllerCreate4WithResponse(ctx context.Context, params *LogflareWebLogControllerCreate4Params, reqEditors ...RequestEditorFn) (*LogflareWebLogControllerCreate4Response, error) {
-	rsp, err := c.LogflareWebLogControllerCreate4(ctx, params, reqEditors...)
+// LogflareWebLogControllerCreate4WithBodyWithResponse request with arbitrary body returning *LogflareWebLogControllerCreate4Response
+func (c *ClientWithResponses) LogflareWebLogControllerCreate4WithBodyWithResponse(ctx context.Context, params *LogflareWebLogControllerCreate4Params, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*LogflareWebLogControllerCreate4Response, error) {
+	rsp, err := c.LogflareWebLogControllerCreate4WithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLogflareWebLogControllerCreate4Response(rsp)
+}
+
+func (c *ClientWithResponses) LogflareWebLogControllerCreate4WithResponse(ctx context.Context, params *LogflareWebLogControllerCreate4Params, body LogflareWebLogControllerCreate4JSONRequestBody, reqEditors ...RequestEditorFn) (*LogflareWebLogControllerCreate4Response, error) {
+	rsp, err := c.LogflareWebLogControllerCreate4(ctx, params, body, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
@@ -5201,6 +5353,15 @@ func (c *ClientWithResponses) LogflareWebApiTeamControllerUpdateWithResponse(ctx
 	return ParseLogflareWebApiTeamControllerUpdateResponse(rsp)
 }
 
+// LogflareWebHealthCheckControllerCheckWithResponse request returning *LogflareWebHealthCheckControllerCheckResponse
+func (c *ClientWithResponses) LogflareWebHealthCheckControllerCheckWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*LogflareWebHealthCheckControllerCheckResponse, error) {
+	rsp, err := c.LogflareWebHealthCheckControllerCheck(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLogflareWebHealthCheckControllerCheckResponse(rsp)
+}
+
 // ParseLogflareWebApiAccessTokenControllerIndexResponse parses an HTTP response from a LogflareWebApiAccessTokenControllerIndexWithResponse call
 func ParseLogflareWebApiAccessTokenControllerIndexResponse(rsp *http.Response) (*LogflareWebApiAccessTokenControllerIndexResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -6370,3 +6531,29 @@ func ParseLogflareWebApiTeamControllerUpdateResponse(rsp *http.Response) (*Logfl
 
 	return response, nil
 }
+
+// ParseLogflareWebHealthCheckControllerCheckResponse parses an HTTP response from a LogflareWebHealthCheckControllerCheckWithResponse call
+func ParseLogflareWebHealthCheckControllerCheckResponse(rsp *http.Response) (*LogflareWebHealthCheckControllerCheckResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &LogflareWebHealthCheckControllerCheckResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest HealthCheck
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
@@ -6,7 +6,10 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
 	"log"
+	"os"
+	"strings"
 
 	"github.com/supabase/terraform-provider-supabase-analytics/provider"
 
@@ -25,16 +28,22 @@ var (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		if err := runExport(os.Args[2:]); err != nil {
+			log.Fatal(err.Error())
+		}
+		return
+	}
+
 	var debug bool
 
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
 	opts := providerserver.ServeOpts{
-		// TODO: Update this string with the published name of your provider.
-		// Also update the tfplugindocs generate command to either remove the
-		// -provider-name flag or set its value to the updated provider name.
-		Address: "registry.terraform.io/logflare/logflare",
+		// The provider was first published as registry.terraform.io/logflare/logflare.
+		// Resources accept moved blocks from that address, see provider/move_state.go.
+		Address: "registry.terraform.io/supabase/supabase-analytics",
 		Debug:   debug,
 	}
 
@@ -44,3 +53,56 @@ func main() {
 		log.Fatal(err.Error())
 	}
 }
+
+// runExport generates Terraform configuration and import blocks for an
+// existing Logflare account.
+func runExport(args []string) error {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	host := flags.String("host", "", "URI for Logflare API (default from LOGFLARE_HOST or LOGFLARE_API_URL, then https://logflare.app)")
+	out := flags.String("out", ".", "directory to write the generated .tf files to")
+	caCert := flags.String("ca-cert", "", "PEM-encoded CA certificate, or path to one, trusted in addition to the system roots")
+	clientCert := flags.String("client-cert", "", "PEM-encoded client certificate, or path to one, for mutual TLS")
+	clientKey := flags.String("client-key", "", "PEM-encoded private key of -client-cert, or path to one")
+	insecureSkipVerify := flags.Bool("insecure-skip-verify", false, "do not verify the server certificate, for local development only")
+	headers := headerFlag{}
+	flags.Var(headers, "header", "additional `name=value` header sent with every request; may be repeated")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s export [flags]\n\n", os.Args[0])
+		fmt.Fprintln(flags.Output(), "Writes .tf files with import blocks for all backends, sources, endpoints and rules of the account.")
+		fmt.Fprintln(flags.Output(), "Teams are not exported. The access token is read from the LOGFLARE_ACCESS_TOKEN environment variable.")
+		fmt.Fprintln(flags.Output())
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	return provider.Export(context.Background(), provider.ExportConfig{
+		Host:               *host,
+		AccessToken:        os.Getenv("LOGFLARE_ACCESS_TOKEN"),
+		OutputDir:          *out,
+		Log:                os.Stderr,
+		Version:            version,
+		CACert:             *caCert,
+		ClientCert:         *clientCert,
+		ClientKey:          *clientKey,
+		InsecureSkipVerify: *insecureSkipVerify,
+		Headers:            headers,
+	})
+}
+
+// headerFlag collects repeated -header name=value flags.
+type headerFlag map[string]string
+
+func (h headerFlag) String() string {
+	return ""
+}
+
+func (h headerFlag) Set(value string) error {
+	name, headerValue, ok := strings.Cut(value, "=")
+	if !ok || name == "" {
+		return fmt.Errorf("expected name=value, got %q", value)
+	}
+	h[name] = headerValue
+	return nil
+}
@@ -0,0 +1,51 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/querycheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccBackendsListResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccBackendsListResourceConfig,
+			},
+			{
+				Query:  true,
+				Config: providerConfig + testAccBackendsListResourceQuery,
+				QueryResultChecks: []querycheck.QueryResultCheck{
+					querycheck.ExpectLength("logflare_backend.listed", 1),
+				},
+			},
+		},
+	})
+}
+
+const testAccBackendsListResourceConfig = `
+resource "logflare_backend" "backend_list_test" {
+	name   = "my-listed-backend"
+	type   = "webhook"
+	config = jsonencode({ url = "https://example.com/listed" })
+}
+`
+
+const testAccBackendsListResourceQuery = `
+list "logflare_backend" "listed" {
+	provider = logflare
+
+	config {
+		name_regex = "^my-listed-backend$"
+	}
+}
+`
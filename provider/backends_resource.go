@@ -0,0 +1,385 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/supabase/terraform-provider-supabase-analytics/internal/pkg/api"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                 = &BackendResource{}
+	_ resource.ResourceWithIdentity     = &BackendResource{}
+	_ resource.ResourceWithImportState  = &BackendResource{}
+	_ resource.ResourceWithUpgradeState = &BackendResource{}
+)
+
+func NewBackendResource() resource.Resource {
+	return &BackendResource{}
+}
+
+// BackendResource defines the resource implementation.
+type BackendResource struct {
+	client *api.ClientWithResponses
+}
+
+// BackendResourceModel describes the resource data model.
+type BackendResourceModel struct {
+	Config        jsontypes.Normalized `tfsdk:"config"`
+	DefaultIngest types.Bool           `tfsdk:"default_ingest"`
+	Id            types.Int64          `tfsdk:"id"`
+	Metadata      jsontypes.Normalized `tfsdk:"metadata"`
+	Name          types.String         `tfsdk:"name"`
+	Token         types.String         `tfsdk:"token"`
+	Type          types.String         `tfsdk:"type"`
+	Timeouts      timeouts.Value       `tfsdk:"timeouts"`
+}
+
+func (r *BackendResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_backend"
+}
+
+func (r *BackendResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Backend resource. Backends store the events of the sources attached to them and run the queries of endpoints.",
+		Version:             int64(len(backendStateUpgradeSteps)),
+
+		Attributes: map[string]schema.Attribute{
+			"config": schema.StringAttribute{
+				CustomType:          jsontypes.NormalizedType{},
+				MarkdownDescription: "Configuration of the backend as JSON. The expected keys depend on `type`, for example `url` for `webhook` backends.",
+				Required:            true,
+				Sensitive:           true,
+			},
+			"default_ingest": schema.BoolAttribute{
+				MarkdownDescription: "Whether the backend receives the events of sources that ingest into the default backend.",
+				Computed:            true,
+			},
+			"id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Backend identifier",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"metadata": schema.StringAttribute{
+				CustomType:          jsontypes.NormalizedType{},
+				MarkdownDescription: "Metadata of the backend as JSON",
+				Optional:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the backend",
+				Required:            true,
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "Token of the backend, used to attach it to sources in `backend_tokens`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Type of the backend, such as `bigquery`, `clickhouse`, `postgres` or `webhook`. Changing it replaces the backend.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *BackendResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return stateUpgraders(backendStateUpgradeSteps...)
+}
+
+// backendStateUpgradeSteps upgrade the state of logflare_backend one schema
+// version at a time. The schema version is the number of steps; append a step
+// whenever an attribute changes type.
+var backendStateUpgradeSteps = []stateUpgradeStep{}
+
+func (r *BackendResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = tokenIdentitySchema()
+}
+
+func (r *BackendResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*logflareProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *logflareProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *BackendResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data BackendResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(withTimeoutDiagnostics(ctx, createBackend(ctx, &data, r.client), "create", "logflare_backend", createTimeout)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created a resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, tokenIdentityModel{Token: data.Token})...)
+}
+
+func createBackend(ctx context.Context, data *BackendResourceModel, client *api.ClientWithResponses) diag.Diagnostics {
+	body, diags := backendResourceToApiSchema(data)
+	if diags.HasError() {
+		return diags
+	}
+
+	httpResp, err := client.LogflareWebApiBackendControllerCreateWithResponse(ctx, body)
+	if err != nil {
+		msg := fmt.Sprintf("Unable to create backend, got error: %s", err)
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", msg)}
+	}
+
+	if httpResp.JSON201 == nil {
+		msg := fmt.Sprintf("Unable to create backend, got status %d: %s", httpResp.StatusCode(), httpResp.Body)
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", msg)}
+	}
+
+	return backendApiSchemaToModel(httpResp.JSON201, data)
+}
+
+func (r *BackendResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data BackendResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	if data.Token.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(withTimeoutDiagnostics(ctx, readBackend(ctx, &data, r.client), "read", "logflare_backend", readTimeout)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, tokenIdentityModel{Token: data.Token})...)
+}
+
+func readBackend(ctx context.Context, data *BackendResourceModel, client *api.ClientWithResponses) diag.Diagnostics {
+	httpResp, err := client.LogflareWebApiBackendControllerShowWithResponse(ctx, data.Token.ValueString())
+	if err != nil {
+		msg := fmt.Sprintf("Unable to read backend, got error: %s", err)
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", msg)}
+	}
+
+	if httpResp.JSON200 == nil {
+		msg := fmt.Sprintf("Unable to read backend, got status %d: %s", httpResp.StatusCode(), httpResp.Body)
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", msg)}
+	}
+
+	return backendApiSchemaToModel(httpResp.JSON200, data)
+}
+
+func (r *BackendResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data BackendResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(withTimeoutDiagnostics(ctx, updateBackend(ctx, &data, r.client), "update", "logflare_backend", updateTimeout)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, tokenIdentityModel{Token: data.Token})...)
+}
+
+func updateBackend(ctx context.Context, data *BackendResourceModel, client *api.ClientWithResponses) diag.Diagnostics {
+	body, diags := backendResourceToApiSchema(data)
+	if diags.HasError() {
+		return diags
+	}
+
+	httpResp, err := client.LogflareWebApiBackendControllerUpdateWithResponse(ctx, data.Token.ValueString(), body)
+	if err != nil {
+		msg := fmt.Sprintf("Unable to update backend, got error: %s", err)
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", msg)}
+	}
+
+	if httpResp.JSON200 == nil {
+		msg := fmt.Sprintf("Unable to update backend, got status %d: %s", httpResp.StatusCode(), httpResp.Body)
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", msg)}
+	}
+
+	return backendApiSchemaToModel(httpResp.JSON200, data)
+}
+
+func (r *BackendResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data BackendResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	if data.Token.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(withTimeoutDiagnostics(ctx, deleteBackend(ctx, &data, r.client), "delete", "logflare_backend", deleteTimeout)...)
+}
+
+func deleteBackend(ctx context.Context, data *BackendResourceModel, client *api.ClientWithResponses) diag.Diagnostics {
+	httpResp, err := client.LogflareWebApiBackendControllerDeleteWithResponse(ctx, data.Token.ValueString())
+	if err != nil {
+		msg := fmt.Sprintf("Unable to delete backend, got error: %s", err)
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", msg)}
+	}
+
+	if httpResp.HTTPResponse.StatusCode != 204 {
+		msg := fmt.Sprintf("Unable to delete backend, got status %d: %s", httpResp.StatusCode(), httpResp.Body)
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", msg)}
+	}
+
+	return nil
+}
+
+func (r *BackendResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("token"), path.Root("token"), req, resp)
+}
+
+// backendApiSchemaToModel copies result into data. The server may leave out
+// the config of a backend, in which case the configured one is kept.
+func backendApiSchemaToModel(result *api.BackendApiSchema, data *BackendResourceModel) diag.Diagnostics {
+	data.DefaultIngest = types.BoolValue(result.DefaultIngest != nil && *result.DefaultIngest)
+	data.Id = types.Int64PointerValue(intPtrToInt64Ptr(result.Id))
+	data.Name = types.StringValue(result.Name)
+	data.Token = types.StringPointerValue(result.Token)
+	data.Type = types.StringPointerValue(result.Type)
+
+	if result.Config != nil {
+		value, err := json.Marshal(result.Config)
+		if err != nil {
+			return diag.Diagnostics{diag.NewErrorDiagnostic("Can't encode config field", err.Error())}
+		}
+		data.Config = jsontypes.NewNormalizedValue(string(value))
+	}
+
+	data.Metadata = jsontypes.NewNormalizedNull()
+	if result.Metadata != nil && len(*result.Metadata) > 0 {
+		value, err := json.Marshal(result.Metadata)
+		if err != nil {
+			return diag.Diagnostics{diag.NewErrorDiagnostic("Can't encode metadata field", err.Error())}
+		}
+		data.Metadata = jsontypes.NewNormalizedValue(string(value))
+	}
+
+	return nil
+}
+
+func backendResourceToApiSchema(data *BackendResourceModel) (api.BackendApiSchema, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var config, metadata *map[string]any
+	if !data.Config.IsNull() && !data.Config.IsUnknown() {
+		diags.Append(data.Config.Unmarshal(&config)...)
+	}
+	if !data.Metadata.IsNull() && !data.Metadata.IsUnknown() {
+		diags.Append(data.Metadata.Unmarshal(&metadata)...)
+	}
+
+	body := api.BackendApiSchema{
+		Config:   config,
+		Metadata: metadata,
+		Name:     data.Name.ValueString(),
+		Type:     data.Type.ValueStringPointer(),
+	}
+
+	return body, diags
+}
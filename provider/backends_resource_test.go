@@ -0,0 +1,91 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/supabase/terraform-provider-supabase-analytics/internal/pkg/api"
+)
+
+func TestAccBackendsResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccBackendsResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("logflare_backend.backend_test", "name", "my-webhook-backend"),
+					resource.TestCheckResourceAttr("logflare_backend.backend_test", "type", "webhook"),
+					resource.TestCheckResourceAttrSet("logflare_backend.backend_test", "token"),
+				),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentityValueMatchesState("logflare_backend.backend_test", tfjsonpath.New("token")),
+				},
+			},
+			// Import by identity.
+			{
+				ResourceName:    "logflare_backend.backend_test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+		},
+	})
+}
+
+const testAccBackendsResourceConfig = `
+resource "logflare_backend" "backend_test" {
+	name   = "my-webhook-backend"
+	type   = "webhook"
+	config = jsonencode({ url = "https://example.com/webhook" })
+}
+`
+
+func TestBackendApiSchemaRoundTrip(t *testing.T) {
+	id, defaultIngest := 3, true
+	token, backendType := "backend-token", "webhook"
+	result := api.BackendApiSchema{
+		Config:        &map[string]any{"url": "https://example.com/webhook"},
+		DefaultIngest: &defaultIngest,
+		Id:            &id,
+		Metadata:      &map[string]any{},
+		Name:          "my-webhook-backend",
+		Token:         &token,
+		Type:          &backendType,
+	}
+
+	var data BackendResourceModel
+	if diags := backendApiSchemaToModel(&result, &data); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if data.Id.ValueInt64() != 3 || data.Type.ValueString() != "webhook" || !data.DefaultIngest.ValueBool() {
+		t.Errorf("unexpected model %+v", data)
+	}
+	if data.Config.ValueString() != `{"url":"https://example.com/webhook"}` {
+		t.Errorf("unexpected config %s", data.Config)
+	}
+	if !data.Metadata.IsNull() {
+		t.Errorf("expected empty metadata to be null, got %s", data.Metadata)
+	}
+
+	body, diags := backendResourceToApiSchema(&data)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if body.Name != "my-webhook-backend" || *body.Type != "webhook" || (*body.Config)["url"] != "https://example.com/webhook" || body.Metadata != nil {
+		t.Errorf("unexpected body %+v", body)
+	}
+
+	// Backends read without their config keep the configured one.
+	result.Config = nil
+	if diags := backendApiSchemaToModel(&result, &data); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if data.Config.ValueString() != `{"url":"https://example.com/webhook"}` {
+		t.Errorf("expected the config to be kept, got %s", data.Config)
+	}
+}
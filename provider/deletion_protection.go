@@ -0,0 +1,75 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/supabase/terraform-provider-supabase-analytics/internal/pkg/api"
+)
+
+// Defaults of deletion_protection. Deleting a source drops its table and all
+// retained events, so only sources are protected unless configured otherwise.
+const (
+	sourceDeletionProtectionDefault   = true
+	endpointDeletionProtectionDefault = false
+)
+
+// deletionProtected reports whether the deletion_protection value in state
+// prevents deletion. States written before the attribute existed hold null,
+// which counts as the default of the resource.
+func deletionProtected(value types.Bool, defaultValue bool) bool {
+	if value.IsNull() || value.IsUnknown() {
+		return defaultValue
+	}
+	return value.ValueBool()
+}
+
+func deletionProtectionDiagnostic(typeName, name string) diag.Diagnostic {
+	return diag.NewErrorDiagnostic(
+		"Deletion Protection Enabled",
+		fmt.Sprintf("%s %q has deletion_protection enabled and cannot be deleted. "+
+			"Set deletion_protection to false and apply that change before destroying or replacing it.", typeName, name),
+	)
+}
+
+// checkSourceRecentEvents refuses the deletion of a source that ingested
+// events within window before now, as reported by its recent events.
+func checkSourceRecentEvents(ctx context.Context, client *api.ClientWithResponses, data *SourceResourceModel, window time.Duration, now time.Time) diag.Diagnostics {
+	httpResp, err := client.LogflareWebApiSourceControllerRecentWithResponse(ctx, data.Token.ValueString())
+	if err != nil {
+		msg := fmt.Sprintf("Unable to check the recent events of the source before deleting it, got error: %s", err)
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", msg)}
+	}
+
+	if httpResp.JSON200 == nil {
+		msg := fmt.Sprintf("Unable to check the recent events of the source before deleting it, got status %d: %s", httpResp.StatusCode(), httpResp.Body)
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", msg)}
+	}
+
+	var latest time.Time
+	for _, event := range *httpResp.JSON200 {
+		// Logflare event timestamps are in microseconds since the epoch.
+		if event.Timestamp != nil {
+			if ts := time.UnixMicro(int64(*event.Timestamp)); ts.After(latest) {
+				latest = ts
+			}
+		}
+	}
+
+	if latest.IsZero() || now.Sub(latest) > window {
+		return nil
+	}
+
+	return diag.Diagnostics{diag.NewErrorDiagnostic(
+		"Source Recently Ingested Events",
+		fmt.Sprintf("Source %q ingested an event at %s, within the last %s set by deletion_protection_ingest_window_seconds. "+
+			"Stop sending events to it, or unset deletion_protection_ingest_window_seconds and apply that change, before deleting it.",
+			data.Name.ValueString(), latest.UTC().Format(time.RFC3339), window),
+	)}
+}
@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDeletionProtected(t *testing.T) {
+	tests := []struct {
+		value        types.Bool
+		defaultValue bool
+		want         bool
+	}{
+		{types.BoolValue(true), false, true},
+		{types.BoolValue(false), true, false},
+		{types.BoolNull(), true, true},
+		{types.BoolNull(), false, false},
+	}
+
+	for _, tt := range tests {
+		if got := deletionProtected(tt.value, tt.defaultValue); got != tt.want {
+			t.Errorf("deletionProtected(%s, %t) = %t, want %t", tt.value, tt.defaultValue, got, tt.want)
+		}
+	}
+}
+
+func TestCheckSourceRecentEvents(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/sources/active-token/recent":
+			_, _ = fmt.Fprintf(w, `[{"event_message": "old", "timestamp": %d}, {"event_message": "new", "timestamp": %d}]`,
+				now.Add(-48*time.Hour).UnixMicro(), now.Add(-10*time.Minute).UnixMicro())
+		case "/api/sources/idle-token/recent":
+			_, _ = io.WriteString(w, `[]`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := newLogflareClient(clientConfig{host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	tests := []struct {
+		name    string
+		token   string
+		window  time.Duration
+		wantErr bool
+	}{
+		{"event within window", "active-token", time.Hour, true},
+		{"events before window", "active-token", 5 * time.Minute, false},
+		{"no events", "idle-token", time.Hour, false},
+		{"unknown source", "missing-token", time.Hour, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := SourceResourceModel{Name: types.StringValue("requests"), Token: types.StringValue(tt.token)}
+			diags := checkSourceRecentEvents(ctx, client, &data, tt.window, now)
+			if diags.HasError() != tt.wantErr {
+				t.Errorf("expected error %t, got %v", tt.wantErr, diags)
+			}
+		})
+	}
+}
+
+func TestImportStateDeletionProtectionDefault(t *testing.T) {
+	ctx := context.Background()
+
+	for r, want := range map[resource.ResourceWithImportState]bool{
+		&SourceResource{}:   true,
+		&EndpointResource{}: false,
+	} {
+		state, identity := emptyTestState(t, r)
+
+		resp := resource.ImportStateResponse{State: state, Identity: identity}
+		r.ImportState(ctx, resource.ImportStateRequest{ID: "imported-token"}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("%T: unexpected diagnostics: %v", r, resp.Diagnostics)
+		}
+
+		var protection types.Bool
+		resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("deletion_protection"), &protection)...)
+		if protection.IsNull() || protection.ValueBool() != want {
+			t.Errorf("%T: expected deletion_protection to be imported as %t, got %s", r, want, protection)
+		}
+	}
+}
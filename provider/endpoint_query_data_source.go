@@ -113,7 +113,8 @@ func readEndpoints(ctx context.Context, data *EndpointQueryDataSourceModel, clie
 	}
 
 	if httpResp.JSON200.Error != nil {
-		msg := fmt.Sprintf("Endpoints API returned an error: %s", httpResp.JSON200.Error)
+		apiError, _ := httpResp.JSON200.Error.MarshalJSON()
+		msg := fmt.Sprintf("Endpoints API returned an error: %s", apiError)
 		return diag.Diagnostics{diag.NewErrorDiagnostic("Response Error", msg)}
 	}
 
@@ -0,0 +1,206 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/supabase/terraform-provider-supabase-analytics/internal/pkg/api"
+)
+
+// queryTableNames returns the names of the sources that query reads from, in
+// order of first appearance. Names of common table expressions and table
+// functions are left out, as they do not refer to sources, and so are names
+// qualified with unquoted dots such as project.dataset.table, which refer to
+// tables outside of the sources. A quoted name that contains dots, such as
+// `postgres.logs`, is a single source name. Anything else the parser cannot
+// classify is skipped rather than guessed at.
+func queryTableNames(query string) []string {
+	tokens := sqlTokens(query)
+	ctes := sqlCTENames(tokens)
+
+	var names []string
+	add := func(name string) {
+		if !ctes[name] && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+
+	for i := 0; i < len(tokens); i++ {
+		if tokens[i] != "from" && tokens[i] != "join" {
+			continue
+		}
+		inFrom := tokens[i] == "from"
+
+		for i+1 < len(tokens) {
+			name, qualified, next := readSQLTableName(tokens, i+1)
+			if name == "" {
+				break
+			}
+			// A name followed by arguments is a table function.
+			if next < len(tokens) && tokens[next] == "(" {
+				break
+			}
+			if !qualified {
+				add(name)
+			}
+			i = next - 1
+
+			// Skip an optional alias.
+			if i+1 < len(tokens) && tokens[i+1] == "as" {
+				i++
+			}
+			if i+1 < len(tokens) && isSQLIdentifier(tokens[i+1]) && !sqlKeywords[tokens[i+1]] {
+				i++
+			}
+
+			// A FROM clause can list several tables.
+			if !inFrom || i+1 >= len(tokens) || tokens[i+1] != "," {
+				break
+			}
+			i++
+		}
+	}
+
+	return names
+}
+
+// sqlCTENames returns the names of the common table expressions defined
+// anywhere in tokens, including nested WITH clauses and expressions with a
+// column list or a materialization hint.
+func sqlCTENames(tokens []string) map[string]bool {
+	ctes := map[string]bool{}
+
+	for i := 0; i < len(tokens); i++ {
+		if !isSQLIdentifier(tokens[i]) || sqlKeywords[tokens[i]] {
+			continue
+		}
+
+		j := i + 1
+		// A column list is only told apart from a function call by the
+		// WITH clause it appears in.
+		if j < len(tokens) && tokens[j] == "(" && i > 0 && (tokens[i-1] == "with" || tokens[i-1] == "recursive" || tokens[i-1] == ",") {
+			j = skipSQLParentheses(tokens, j)
+		}
+		if j >= len(tokens) || tokens[j] != "as" {
+			continue
+		}
+		j++
+		if j < len(tokens) && tokens[j] == "not" {
+			j++
+		}
+		if j < len(tokens) && strings.EqualFold(tokens[j], "materialized") {
+			j++
+		}
+		if j < len(tokens) && tokens[j] == "(" {
+			ctes[unquoteSQLIdentifier(tokens[i])] = true
+		}
+	}
+
+	return ctes
+}
+
+// skipSQLParentheses returns the index of the token following the
+// parenthesis that closes the one at tokens[i].
+func skipSQLParentheses(tokens []string, i int) int {
+	depth := 0
+	for ; i < len(tokens); i++ {
+		switch tokens[i] {
+		case "(":
+			depth++
+		case ")":
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return i
+}
+
+// readSQLTableName reads a possibly dotted table name starting at tokens[i]
+// and returns it together with the index of the token following it. The name
+// is qualified when its parts are separated by unquoted dots; dots inside a
+// single quoted identifier are part of the name.
+func readSQLTableName(tokens []string, i int) (string, bool, int) {
+	if i >= len(tokens) || !isSQLIdentifier(tokens[i]) || sqlKeywords[tokens[i]] || tokens[i] == "unnest" {
+		return "", false, i
+	}
+
+	parts := []string{unquoteSQLIdentifier(tokens[i])}
+	i++
+	for i+1 < len(tokens) && tokens[i] == "." && isSQLIdentifier(tokens[i+1]) {
+		parts = append(parts, unquoteSQLIdentifier(tokens[i+1]))
+		i += 2
+	}
+
+	return strings.Join(parts, "."), len(parts) > 1, i
+}
+
+func isSQLIdentifier(token string) bool {
+	if token == "" {
+		return false
+	}
+	if token[0] == '`' || token[0] == '"' {
+		return true
+	}
+	return isSQLWordRune([]rune(token)[0])
+}
+
+func unquoteSQLIdentifier(token string) string {
+	if len(token) >= 2 && (token[0] == '`' || token[0] == '"') && token[len(token)-1] == token[0] {
+		return token[1 : len(token)-1]
+	}
+	return token
+}
+
+// resolveSourceMapping maps each table name to the token of the source with
+// that name. Names that do not match a source are reported as errors on the
+// query attribute.
+func resolveSourceMapping(ctx context.Context, client *api.ClientWithResponses, names []string) (map[string]string, diag.Diagnostics) {
+	mapping := map[string]string{}
+	if len(names) == 0 {
+		return mapping, nil
+	}
+
+	httpResp, err := client.LogflareWebApiSourceControllerIndexWithResponse(ctx)
+	if err != nil {
+		msg := fmt.Sprintf("Unable to list sources, got error: %s", err)
+		return nil, diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", msg)}
+	}
+
+	if httpResp.JSON200 == nil {
+		msg := fmt.Sprintf("Unable to list sources, got status %d: %s", httpResp.StatusCode(), httpResp.Body)
+		return nil, diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", msg)}
+	}
+
+	tokens := map[string]string{}
+	for _, source := range *httpResp.JSON200 {
+		if source.Token != nil {
+			tokens[source.Name] = *source.Token
+		}
+	}
+
+	var diags diag.Diagnostics
+	for _, name := range names {
+		token, ok := tokens[name]
+		if !ok {
+			diags.AddAttributeError(
+				path.Root("query"),
+				"Unknown Source",
+				fmt.Sprintf("The query reads from %q, but there is no source with that name. "+
+					"Create the source before the endpoint or set source_mapping explicitly.", name),
+			)
+			continue
+		}
+		mapping[name] = token
+	}
+
+	return mapping, diags
+}
@@ -0,0 +1,135 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestQueryTableNames(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{
+			name:  "single table",
+			query: "select id from my_source where id > 1",
+			want:  []string{"my_source"},
+		},
+		{
+			name:  "joins and aliases",
+			query: "SELECT a.id FROM `requests` AS a JOIN errors b ON a.id = b.id LEFT JOIN \"Audit Log\" USING (id)",
+			want:  []string{"requests", "errors", "Audit Log"},
+		},
+		{
+			name:  "comma separated tables",
+			query: "select * from first_source f, second_source where f.id = 1",
+			want:  []string{"first_source", "second_source"},
+		},
+		{
+			name:  "common table expressions",
+			query: "with recent as (select * from events limit 10) select * from recent join events on true",
+			want:  []string{"events"},
+		},
+		{
+			name:  "subqueries and unnest",
+			query: "select count(*) from (select m from logs cross join unnest(metadata) as m) -- from ignored",
+			want:  []string{"logs"},
+		},
+		{
+			name:  "nested common table expressions",
+			query: "with outer_cte as (with inner_cte as (select * from events) select * from inner_cte) select * from outer_cte join errors on true",
+			want:  []string{"events", "errors"},
+		},
+		{
+			name:  "common table expressions with column lists",
+			query: "with recursive counts (n, total) as (select 1, 1 union all select n + 1, total from counts), latest as materialized (select * from events) select * from counts, latest",
+			want:  []string{"events"},
+		},
+		{
+			name:  "qualified names",
+			query: "select * from project.dataset.table join `project`.`dataset`.other using (id) join requests using (id)",
+			want:  []string{"requests"},
+		},
+		{
+			name:  "quoted names with dots",
+			query: "select * from `postgres.logs` as l join \"auth.audit\" using (id) join project.dataset.table using (id)",
+			want:  []string{"postgres.logs", "auth.audit"},
+		},
+		{
+			name:  "table functions",
+			query: "select * from generate_series(1, 10) as n join requests on true",
+			want:  []string{"requests"},
+		},
+		{
+			name:  "no tables",
+			query: "select current_date as date",
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := queryTableNames(tt.query); !slices.Equal(got, tt.want) {
+				t.Errorf("queryTableNames(%q) = %q, want %q", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveSourceMapping(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `[
+			{"name": "requests", "token": "5c0a1cb4-8c5a-4e52-a4a5-1bcbb0a1bd9b"},
+			{"name": "errors", "token": "9a7d4c1e-2f8b-4f51-9f3c-5bd0c2c7a2e1"}
+		]`)
+	}))
+	defer server.Close()
+
+	client, err := newLogflareClient(clientConfig{host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mapping, diags := resolveSourceMapping(context.Background(), client, []string{"requests", "errors"})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if len(mapping) != 2 || mapping["requests"] != "5c0a1cb4-8c5a-4e52-a4a5-1bcbb0a1bd9b" {
+		t.Errorf("unexpected mapping %v", mapping)
+	}
+
+	if _, diags := resolveSourceMapping(context.Background(), client, []string{"requests", "missing"}); !diags.HasError() {
+		t.Error("expected an error for a missing source")
+	}
+}
+
+func TestEndpointResourceImportStateAutoSourceMapping(t *testing.T) {
+	ctx := context.Background()
+	r := &EndpointResource{}
+	state, identity := emptyTestState(t, r)
+
+	resp := resource.ImportStateResponse{State: state, Identity: identity}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: "imported-token"}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var autoSourceMapping types.Bool
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("auto_source_mapping"), &autoSourceMapping)...)
+	if autoSourceMapping.IsNull() || autoSourceMapping.ValueBool() {
+		t.Errorf("expected auto_source_mapping to be imported as false, got %s", autoSourceMapping)
+	}
+}
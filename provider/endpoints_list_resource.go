@@ -0,0 +1,130 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/supabase/terraform-provider-supabase-analytics/internal/pkg/api"
+)
+
+var (
+	_ list.ListResource              = &EndpointListResource{}
+	_ list.ListResourceWithConfigure = &EndpointListResource{}
+)
+
+func NewEndpointListResource() list.ListResource {
+	return &EndpointListResource{}
+}
+
+// EndpointListResource lists existing endpoints for `terraform query`.
+type EndpointListResource struct {
+	client *api.ClientWithResponses
+}
+
+type EndpointListResourceModel struct {
+	NameRegex types.String `tfsdk:"name_regex"`
+}
+
+func (r *EndpointListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_endpoint"
+}
+
+func (r *EndpointListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		Description: "Lists endpoints.",
+		Attributes: map[string]listschema.Attribute{
+			"name_regex": listschema.StringAttribute{
+				Description: "Only list endpoints whose name matches this regular expression.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (r *EndpointListResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.ClientWithResponses)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected *api.ClientWithResponses, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *EndpointListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config EndpointListResourceModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	nameRegex, diags := compileNameRegex(config.NameRegex)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	httpResp, err := r.client.LogflareWebApiEndpointControllerIndexWithResponse(ctx)
+	if err != nil {
+		msg := fmt.Sprintf("Unable to list endpoints, got error: %s", err)
+		stream.Results = list.ListResultsStreamDiagnostics(diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", msg)})
+		return
+	}
+
+	if httpResp.JSON200 == nil {
+		msg := fmt.Sprintf("Unable to list endpoints, got status %d: %s", httpResp.StatusCode(), httpResp.Body)
+		stream.Results = list.ListResultsStreamDiagnostics(diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", msg)})
+		return
+	}
+
+	endpoints := *httpResp.JSON200
+	stream.Results = func(push func(list.ListResult) bool) {
+		var count int64
+		for _, endpoint := range endpoints {
+			if req.Limit > 0 && count >= req.Limit {
+				return
+			}
+			if nameRegex != nil && !nameRegex.MatchString(endpoint.Name) {
+				continue
+			}
+
+			result := req.NewListResult(ctx)
+			result.DisplayName = endpoint.Name
+			result.Diagnostics.Append(result.Identity.Set(ctx, tokenIdentityModel{Token: types.StringPointerValue(endpoint.Token)})...)
+
+			if req.IncludeResource {
+				data := EndpointResourceModel{
+					AutoSourceMapping:  types.BoolValue(false),
+					DeletionProtection: types.BoolValue(endpointDeletionProtectionDefault),
+					Timeouts:           nullTimeouts(),
+				}
+				result.Diagnostics.Append(endpointApiSchemaToModel(&endpoint, &data)...)
+				if !result.Diagnostics.HasError() {
+					result.Diagnostics.Append(result.Resource.Set(ctx, &data)...)
+				}
+			}
+
+			count++
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/querycheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccEndpointsListResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccEndpointsListResourceConfig,
+			},
+			{
+				Query:  true,
+				Config: providerConfig + testAccEndpointsListResourceQuery,
+				QueryResultChecks: []querycheck.QueryResultCheck{
+					querycheck.ExpectLength("logflare_endpoint.listed", 1),
+				},
+			},
+		},
+	})
+}
+
+const testAccEndpointsListResourceConfig = `
+resource "logflare_endpoint" "endpoint_list_test" {
+	name  = "my_listed_endpoint"
+	query = "select current_date as date"
+}
+`
+
+const testAccEndpointsListResourceQuery = `
+list "logflare_endpoint" "listed" {
+	provider = logflare
+
+	config {
+		name_regex = "^my_listed_endpoint$"
+	}
+}
+`
@@ -7,27 +7,39 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"regexp"
 
 	"github.com/supabase/terraform-provider-supabase-analytics/internal/pkg/api"
 
 	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var (
-	_ resource.Resource                = &EndpointResource{}
-	_ resource.ResourceWithImportState = &EndpointResource{}
+	_ resource.Resource                   = &EndpointResource{}
+	_ resource.ResourceWithIdentity       = &EndpointResource{}
+	_ resource.ResourceWithImportState    = &EndpointResource{}
+	_ resource.ResourceWithModifyPlan     = &EndpointResource{}
+	_ resource.ResourceWithUpgradeState   = &EndpointResource{}
+	_ resource.ResourceWithMoveState      = &EndpointResource{}
+	_ resource.ResourceWithValidateConfig = &EndpointResource{}
 )
 
 func NewEndpointResource() resource.Resource {
@@ -37,21 +49,30 @@ func NewEndpointResource() resource.Resource {
 // EndpointResource defines the resource implementation.
 type EndpointResource struct {
 	client *api.ClientWithResponses
+	names  *nameIndex
+	server *serverInfo
 }
 
 // EndpointResourceModel describes the resource data model.
 type EndpointResourceModel struct {
+	AutoSourceMapping          types.Bool           `tfsdk:"auto_source_mapping"`
+	BackendId                  types.Int32          `tfsdk:"backend_id"`
 	CacheDurationSeconds       types.Int32          `tfsdk:"cache_duration_seconds"`
+	DeletionProtection         types.Bool           `tfsdk:"deletion_protection"`
 	Description                types.String         `tfsdk:"description"`
 	EnableAuth                 types.Bool           `tfsdk:"enable_auth"`
 	Id                         types.Int64          `tfsdk:"id"`
+	Labels                     types.String         `tfsdk:"labels"`
+	Language                   types.String         `tfsdk:"language"`
 	MaxLimit                   types.Int32          `tfsdk:"max_limit"`
 	Name                       types.String         `tfsdk:"name"`
 	ProactiveRequeryingSeconds types.Int32          `tfsdk:"proactive_requerying_seconds"`
-	Query                      types.String         `tfsdk:"query"`
+	Query                      SQLQuery             `tfsdk:"query"`
+	RedactPii                  types.Bool           `tfsdk:"redact_pii"`
 	Sandboxable                types.Bool           `tfsdk:"sandboxable"`
 	SourceMapping              jsontypes.Normalized `tfsdk:"source_mapping"`
 	Token                      types.String         `tfsdk:"token"`
+	Timeouts                   timeouts.Value       `tfsdk:"timeouts"`
 }
 
 func (r *EndpointResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -61,14 +82,41 @@ func (r *EndpointResource) Metadata(ctx context.Context, req resource.MetadataRe
 func (r *EndpointResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Endpoint resource",
+		Version:             int64(len(endpointStateUpgradeSteps)),
 
 		Attributes: map[string]schema.Attribute{
+			"auto_source_mapping": schema.BoolAttribute{
+				MarkdownDescription: "Derive `source_mapping` at plan time from the tables referenced in `query`, resolving them to the sources with the same name. " +
+					"Names qualified with unquoted dots, such as `project.dataset.table`, and common table expressions are left out; " +
+					"quote source names that contain dots, such as `` `postgres.logs` ``. " +
+					"The plan fails when a referenced source does not exist. Cannot be combined with an explicit `source_mapping`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"backend_id": schema.Int32Attribute{
+				MarkdownDescription: "Identifier of the backend to run the query on. Defaults to the backend chosen by the server.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.Int32{
+					int32validator.AtLeast(1),
+				},
+				PlanModifiers: []planmodifier.Int32{
+					int32planmodifier.UseStateForUnknown(),
+				},
+			},
 			"cache_duration_seconds": schema.Int32Attribute{
 				MarkdownDescription: "Cache duration in seconds",
 				Optional:            true,
 				Computed:            true,
 				Default:             int32default.StaticInt32(3600),
 			},
+			"deletion_protection": schema.BoolAttribute{
+				MarkdownDescription: "Refuse to delete the endpoint. Set it to `false` and apply that change before destroying or replacing the endpoint.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(endpointDeletionProtectionDefault),
+			},
 			"description": schema.StringAttribute{
 				MarkdownDescription: "Description of the endpoint",
 				Optional:            true,
@@ -86,6 +134,26 @@ func (r *EndpointResource) Schema(ctx context.Context, req resource.SchemaReques
 					int64planmodifier.UseStateForUnknown(),
 				},
 			},
+			"labels": schema.StringAttribute{
+				MarkdownDescription: "Comma-separated labels attached to each query run, as `key` or `key=value` pairs, for example `project,tier=@tier`. " +
+					"Keys start with a lowercase letter and contain up to 63 lowercase letters, digits, underscores or dashes.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(endpointLabelsPattern, "must be a comma-separated list of key or key=value labels"),
+				},
+			},
+			"language": schema.StringAttribute{
+				MarkdownDescription: "Language of the query: `bq_sql` (BigQuery SQL), `ch_sql` (ClickHouse SQL), `pg_sql` (Postgres SQL) or `lql` (Logflare Query Language). " +
+					"Defaults to the language chosen by the server. `bq_sql` and `ch_sql` queries are validated by the server at plan time.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(endpointLanguages...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"max_limit": schema.Int32Attribute{
 				MarkdownDescription: "Maximum limit",
 				Optional:            true,
@@ -103,9 +171,16 @@ func (r *EndpointResource) Schema(ctx context.Context, req resource.SchemaReques
 				Default:             int32default.StaticInt32(1800),
 			},
 			"query": schema.StringAttribute{
-				MarkdownDescription: "Query string",
+				CustomType:          SQLQueryType{},
+				MarkdownDescription: "SQL query of the endpoint. Differences in whitespace, comments and the casing of keywords and function names are not treated as changes.",
 				Required:            true,
 			},
+			"redact_pii": schema.BoolAttribute{
+				MarkdownDescription: "Redact personally identifiable information, such as IP addresses, from query results",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
 			"sandboxable": schema.BoolAttribute{
 				MarkdownDescription: "Whether the endpoint is sandboxable",
 				Optional:            true,
@@ -123,29 +198,209 @@ func (r *EndpointResource) Schema(ctx context.Context, req resource.SchemaReques
 				MarkdownDescription: "Authentication token",
 				Computed:            true,
 				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
 	}
 }
 
+func (r *EndpointResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return stateUpgraders(endpointStateUpgradeSteps...)
+}
+
+// MoveState accepts moved blocks from the logflare_endpoint type of the legacy provider
+// address.
+func (r *EndpointResource) MoveState(_ context.Context) []resource.StateMover {
+	return []resource.StateMover{legacyStateMover("logflare_endpoint", endpointStateUpgradeSteps)}
+}
+
+// endpointStateUpgradeSteps upgrade the state of logflare_endpoint one schema
+// version at a time. The schema version is the number of steps; append a step
+// whenever an attribute changes type.
+var endpointStateUpgradeSteps = []stateUpgradeStep{}
+
+func (r *EndpointResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = tokenIdentitySchema()
+}
+
 func (r *EndpointResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*api.ClientWithResponses)
+	data, ok := req.ProviderData.(*logflareProviderData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *api.ClientWithResponses, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *logflareProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.names = data.names
+	r.server = data.server
+}
+
+func (r *EndpointResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when the resource is being destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.server.validateConfig(ctx, "logflare_endpoint", req.Config)...)
+
+	name, ownToken, checkName, diags := planNameCheck(ctx, req)
+	resp.Diagnostics.Append(diags...)
+	if checkName && r.client != nil {
+		resp.Diagnostics.Append(r.names.checkEndpointName(ctx, r.client, name, ownToken)...)
+	}
+
+	var query, priorQuery SQLQuery
+	var language, priorLanguage types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("query"), &query)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("language"), &language)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.planSourceMapping(ctx, query, language, req, resp)...)
+
+	if resp.Diagnostics.HasError() || query.IsUnknown() || r.client == nil {
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("query"), &priorQuery)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("language"), &priorLanguage)...)
+		if query.Equal(priorQuery) && language.Equal(priorLanguage) {
+			return
+		}
+	}
+
+	// The language is unknown when it is left to the server, which picks
+	// bq_sql for new endpoints.
+	queryLanguage := language.ValueString()
+	if language.IsUnknown() {
+		queryLanguage = string(api.BqSql)
+	}
+
+	resp.Diagnostics.Append(validateEndpointQuery(ctx, r.client, query.ValueString(), queryLanguage)...)
+}
+
+func (r *EndpointResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var autoSourceMapping types.Bool
+	var sourceMapping jsontypes.Normalized
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("auto_source_mapping"), &autoSourceMapping)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("source_mapping"), &sourceMapping)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if autoSourceMapping.ValueBool() && !sourceMapping.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("source_mapping"),
+			"Conflicting Source Mapping",
+			"source_mapping cannot be set when auto_source_mapping is enabled, as it is derived from the query.",
+		)
+	}
+}
+
+// planSourceMapping replaces the planned source_mapping with the one derived
+// from query when auto_source_mapping is enabled.
+func (r *EndpointResource) planSourceMapping(ctx context.Context, query SQLQuery, language types.String, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) diag.Diagnostics {
+	var autoSourceMapping types.Bool
+	diags := req.Plan.GetAttribute(ctx, path.Root("auto_source_mapping"), &autoSourceMapping)
+	if diags.HasError() || !autoSourceMapping.ValueBool() {
+		return diags
+	}
+
+	if language.ValueString() == string(api.Lql) {
+		diags.AddAttributeError(
+			path.Root("auto_source_mapping"),
+			"Unsupported Query Language",
+			"auto_source_mapping can only derive sources from SQL queries. Set source_mapping explicitly for lql endpoints.",
+		)
+		return diags
+	}
+
+	if query.IsUnknown() || r.client == nil {
+		return append(diags, resp.Plan.SetAttribute(ctx, path.Root("source_mapping"), jsontypes.NewNormalizedUnknown())...)
+	}
+
+	mapping, mappingDiags := resolveSourceMapping(ctx, r.client, queryTableNames(query.ValueString()))
+	diags.Append(mappingDiags...)
+	if diags.HasError() {
+		return diags
+	}
+
+	value, err := json.Marshal(mapping)
+	if err != nil {
+		return append(diags, diag.NewErrorDiagnostic("Can't encode source_mapping field", err.Error()))
+	}
+
+	return append(diags, resp.Plan.SetAttribute(ctx, path.Root("source_mapping"), jsontypes.NewNormalizedValue(string(value)))...)
+}
+
+// endpointLabelsPattern matches the labels of an endpoint: comma-separated
+// keys, each optionally followed by a value.
+var endpointLabelsPattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{0,62}(=[^,=]*)?(,[a-z][a-z0-9_-]{0,62}(=[^,=]*)?)*$`)
+
+// endpointLanguages are the query languages supported by endpoints.
+var endpointLanguages = []string{
+	string(api.BqSql),
+	string(api.ChSql),
+	string(api.PgSql),
+	string(api.Lql),
+}
+
+// validateEndpointQuery asks the server to parse query, so that syntax errors
+// are reported at plan time rather than when the endpoint is saved. Only
+// languages the parse API understands are checked.
+func validateEndpointQuery(ctx context.Context, client *api.ClientWithResponses, query string, language string) diag.Diagnostics {
+	var params api.LogflareWebApiQueryControllerParseParams
+	switch api.EndpointApiSchemaLanguage(language) {
+	case api.BqSql:
+		params.BqSql = &query
+	case api.ChSql:
+		params.ChSql = &query
+	default:
+		return nil
+	}
+
+	httpResp, err := client.LogflareWebApiQueryControllerParseWithResponse(ctx, &params)
+	if err != nil {
+		msg := fmt.Sprintf("Unable to validate endpoint query, got error: %s", err)
+		return diag.Diagnostics{diag.NewWarningDiagnostic("Query Not Validated", msg)}
+	}
+
+	var parseErr string
+	switch {
+	case httpResp.StatusCode() == 400:
+		parseErr = string(httpResp.Body)
+	case httpResp.JSON200 == nil:
+		msg := fmt.Sprintf("Unable to validate endpoint query, got status %d: %s", httpResp.StatusCode(), httpResp.Body)
+		return diag.Diagnostics{diag.NewWarningDiagnostic("Query Not Validated", msg)}
+	case httpResp.JSON200.Errors != nil:
+		errs, _ := httpResp.JSON200.Errors.MarshalJSON()
+		parseErr = string(errs)
+	default:
+		return nil
+	}
+
+	return diag.Diagnostics{diag.NewAttributeErrorDiagnostic(
+		path.Root("query"),
+		"Invalid Endpoint Query",
+		fmt.Sprintf("The server could not parse the %s query: %s", language, parseErr),
+	)}
 }
 
 func (r *EndpointResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -158,7 +413,17 @@ func (r *EndpointResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	resp.Diagnostics.Append(createEndpoint(ctx, &data, r.client)...)
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(withTimeoutDiagnostics(ctx, createEndpoint(ctx, &data, r.client), "create", "logflare_endpoint", createTimeout)...)
+	r.names.forget("endpoint")
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -167,6 +432,7 @@ func (r *EndpointResource) Create(ctx context.Context, req resource.CreateReques
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, tokenIdentityModel{Token: data.Token})...)
 }
 
 func createEndpoint(ctx context.Context, data *EndpointResourceModel, client *api.ClientWithResponses) diag.Diagnostics {
@@ -197,17 +463,37 @@ func (r *EndpointResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	if data.Token.IsNull() {
 		return
 	}
 
-	resp.Diagnostics.Append(readEndpoint(ctx, &data, r.client)...)
+	resp.Diagnostics.Append(withTimeoutDiagnostics(ctx, readEndpoint(ctx, &data, r.client), "read", "logflare_endpoint", readTimeout)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// The API does not know about deletion_protection and auto_source_mapping.
+	// Imported endpoints and states written before the attributes existed get
+	// their defaults.
+	if data.DeletionProtection.IsNull() {
+		data.DeletionProtection = types.BoolValue(endpointDeletionProtectionDefault)
+	}
+	if data.AutoSourceMapping.IsNull() {
+		data.AutoSourceMapping = types.BoolValue(false)
+	}
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, tokenIdentityModel{Token: data.Token})...)
 }
 
 func readEndpoint(ctx context.Context, data *EndpointResourceModel, client *api.ClientWithResponses) diag.Diagnostics {
@@ -237,13 +523,24 @@ func (r *EndpointResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
-	resp.Diagnostics.Append(updateEndpoint(ctx, &data, r.client)...)
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(withTimeoutDiagnostics(ctx, updateEndpoint(ctx, &data, r.client), "update", "logflare_endpoint", updateTimeout)...)
+	r.names.forget("endpoint")
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, tokenIdentityModel{Token: data.Token})...)
 }
 
 func updateEndpoint(ctx context.Context, data *EndpointResourceModel, client *api.ClientWithResponses) diag.Diagnostics {
@@ -275,11 +572,26 @@ func (r *EndpointResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	if data.Token.IsNull() {
 		return
 	}
 
-	resp.Diagnostics.Append(deleteEndpoint(ctx, &data, r.client)...)
+	if deletionProtected(data.DeletionProtection, endpointDeletionProtectionDefault) {
+		resp.Diagnostics.Append(deletionProtectionDiagnostic("logflare_endpoint", data.Name.ValueString()))
+		return
+	}
+
+	resp.Diagnostics.Append(withTimeoutDiagnostics(ctx, deleteEndpoint(ctx, &data, r.client), "delete", "logflare_endpoint", deleteTimeout)...)
+	r.names.forget("endpoint")
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -301,7 +613,9 @@ func deleteEndpoint(ctx context.Context, data *EndpointResourceModel, client *ap
 }
 
 func (r *EndpointResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("token"), path.Root("token"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("deletion_protection"), endpointDeletionProtectionDefault)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("auto_source_mapping"), false)...)
 }
 
 func int32PtrToIntPtr(i *int32) *int {
@@ -320,15 +634,30 @@ func intPtrToInt32Ptr(i *int) *int32 {
 	return &val
 }
 
+func intPtrToInt64Ptr(i *int) *int64 {
+	if i == nil {
+		return nil
+	}
+	val := int64(*i)
+	return &val
+}
+
 func endpointApiSchemaToModel(result *api.EndpointApiSchema, data *EndpointResourceModel) diag.Diagnostics {
 	data.Id = types.Int64Value(int64(*result.Id))
+	data.BackendId = types.Int32PointerValue(intPtrToInt32Ptr(result.BackendId))
 	data.CacheDurationSeconds = types.Int32PointerValue(intPtrToInt32Ptr(result.CacheDurationSeconds))
 	data.Description = types.StringPointerValue(result.Description)
 	data.EnableAuth = types.BoolPointerValue(result.EnableAuth)
+	data.Labels = types.StringPointerValue(result.Labels)
+	if result.Labels != nil && *result.Labels == "" {
+		data.Labels = types.StringNull()
+	}
+	data.Language = types.StringPointerValue((*string)(result.Language))
 	data.MaxLimit = types.Int32PointerValue(intPtrToInt32Ptr(result.MaxLimit))
 	data.Name = types.StringValue(result.Name)
 	data.ProactiveRequeryingSeconds = types.Int32PointerValue(intPtrToInt32Ptr(result.ProactiveRequeryingSeconds))
-	data.Query = types.StringValue(result.Query)
+	data.Query = NewSQLQueryValue(result.Query)
+	data.RedactPii = types.BoolPointerValue(result.RedactPii)
 	data.Sandboxable = types.BoolPointerValue(result.Sandboxable)
 	value, err := json.Marshal(result.SourceMapping)
 	if err != nil {
@@ -343,14 +672,23 @@ func endpointApiSchemaToModel(result *api.EndpointApiSchema, data *EndpointResou
 func endpointResourcetoApiSchema(data *EndpointResourceModel) api.EndpointApiSchema {
 	var source_mapping *map[string]any
 	data.SourceMapping.Unmarshal(&source_mapping)
+	// An unknown backend_id is left to the server.
+	var backendId *int
+	if !data.BackendId.IsUnknown() {
+		backendId = int32PtrToIntPtr(data.BackendId.ValueInt32Pointer())
+	}
 	body := api.EndpointApiSchema{
+		BackendId:                  backendId,
 		CacheDurationSeconds:       int32PtrToIntPtr(data.CacheDurationSeconds.ValueInt32Pointer()),
 		Description:                data.Description.ValueStringPointer(),
 		EnableAuth:                 data.EnableAuth.ValueBoolPointer(),
+		Labels:                     data.Labels.ValueStringPointer(),
+		Language:                   (*api.EndpointApiSchemaLanguage)(data.Language.ValueStringPointer()),
 		MaxLimit:                   int32PtrToIntPtr(data.MaxLimit.ValueInt32Pointer()),
 		Name:                       data.Name.ValueString(),
 		ProactiveRequeryingSeconds: int32PtrToIntPtr(data.ProactiveRequeryingSeconds.ValueInt32Pointer()),
 		Query:                      data.Query.ValueString(),
+		RedactPii:                  data.RedactPii.ValueBoolPointer(),
 		Sandboxable:                data.Sandboxable.ValueBoolPointer(),
 		SourceMapping:              source_mapping,
 		Token:                      data.Token.ValueStringPointer(),
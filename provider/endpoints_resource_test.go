@@ -4,9 +4,17 @@
 package provider
 
 import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/supabase/terraform-provider-supabase-analytics/internal/pkg/api"
 )
 
 func TestEndpointsResource(t *testing.T) {
@@ -19,7 +27,17 @@ func TestEndpointsResource(t *testing.T) {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("logflare_endpoint.endpoint_test", "name", "my_cool_endpoint"),
 					resource.TestCheckResourceAttr("logflare_endpoint.endpoint_test", "enable_auth", "true"),
+					resource.TestCheckResourceAttrSet("logflare_endpoint.endpoint_test", "language"),
 				),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentityValueMatchesState("logflare_endpoint.endpoint_test", tfjsonpath.New("token")),
+				},
+			},
+			// Import by identity.
+			{
+				ResourceName:    "logflare_endpoint.endpoint_test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
 			},
 		},
 	})
@@ -31,3 +49,127 @@ resource "logflare_endpoint" "endpoint_test" {
 	query = "select current_date as date"
 }
 `
+
+func TestValidateEndpointQuery(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("ch_sql") != "" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = io.WriteString(w, `{"error": "Error while parsing SQL"}`)
+			return
+		}
+		if query.Get("bq_sql") == "" {
+			t.Errorf("unexpected query parameters %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"result": {"parameters": []}}`)
+	}))
+	defer server.Close()
+
+	client, err := newLogflareClient(clientConfig{host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diags := validateEndpointQuery(context.Background(), client, "select id from my_source", "bq_sql"); diags.HasError() {
+		t.Errorf("unexpected diagnostics: %v", diags)
+	}
+	if diags := validateEndpointQuery(context.Background(), client, "select from", "ch_sql"); !diags.HasError() {
+		t.Error("expected an error for an invalid query")
+	}
+	if diags := validateEndpointQuery(context.Background(), client, "select from", "pg_sql"); len(diags) != 0 {
+		t.Errorf("pg_sql queries should not be validated, got %v", diags)
+	}
+}
+
+func TestEndpointLabelsPattern(t *testing.T) {
+	for labels, want := range map[string]bool{
+		"project":                   true,
+		"project,tier=@tier":        true,
+		"org_id=@org,env=prod,x-id": true,
+		"":                          false,
+		"Project":                   false,
+		"project,":                  false,
+		"1project":                  false,
+		"project=a=b":               false,
+	} {
+		if got := endpointLabelsPattern.MatchString(labels); got != want {
+			t.Errorf("endpointLabelsPattern.MatchString(%q) = %v, want %v", labels, got, want)
+		}
+	}
+}
+
+func TestEndpointApiSchemaRoundTrip(t *testing.T) {
+	id, backendID := 1, 7
+	labels, redactPII := "project,tier=@tier", true
+	result := api.EndpointApiSchema{
+		BackendId: &backendID,
+		Id:        &id,
+		Labels:    &labels,
+		Name:      "my_endpoint",
+		Query:     "select 1",
+		RedactPii: &redactPII,
+	}
+
+	var data EndpointResourceModel
+	if diags := endpointApiSchemaToModel(&result, &data); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if data.BackendId.ValueInt32() != 7 || data.Labels.ValueString() != labels || !data.RedactPii.ValueBool() {
+		t.Errorf("unexpected model %+v", data)
+	}
+
+	body := endpointResourcetoApiSchema(&data)
+	if *body.BackendId != backendID || *body.Labels != labels || !*body.RedactPii {
+		t.Errorf("unexpected body %+v", body)
+	}
+
+	data.BackendId = types.Int32Unknown()
+	if body := endpointResourcetoApiSchema(&data); body.BackendId != nil {
+		t.Errorf("expected an unknown backend_id to be left out, got %d", *body.BackendId)
+	}
+
+	empty := ""
+	result.Labels = &empty
+	if diags := endpointApiSchemaToModel(&result, &data); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if !data.Labels.IsNull() {
+		t.Errorf("expected empty labels to be null, got %s", data.Labels)
+	}
+}
+
+func TestEndpointResourceModifyPlanDefaultLanguage(t *testing.T) {
+	var parsed string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/endpoints":
+			_, _ = io.WriteString(w, `[]`)
+		case "/api/query/parse":
+			parsed = r.URL.Query().Get("bq_sql")
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = io.WriteString(w, `{"error": "Error while parsing SQL"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := newLogflareClient(clientConfig{host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// An unset language is planned as unknown on create.
+	diags := planTestEndpoint(t, &EndpointResource{client: client, names: &nameIndex{}}, EndpointResourceModel{
+		Language: types.StringUnknown(),
+		Query:    NewSQLQueryValue("select from"),
+	})
+	if parsed != "select from" {
+		t.Errorf("expected the query to be validated as bq_sql, got %q", parsed)
+	}
+	if !diags.HasError() {
+		t.Error("expected an error for an invalid query")
+	}
+}
@@ -0,0 +1,614 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/supabase/terraform-provider-supabase-analytics/internal/pkg/api"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// ExportConfig configures Export.
+type ExportConfig struct {
+	// Host is the URI of the Logflare API. When empty, it is read from the
+	// same environment variables as the provider configuration.
+	Host string
+	// AccessToken authenticates against the Logflare API.
+	AccessToken string
+	// OutputDir is the directory the generated .tf files are written to.
+	OutputDir string
+	// Log receives progress messages and warnings.
+	Log io.Writer
+	// Version is the provider version reported in the User-Agent header.
+	Version string
+
+	// CACert, ClientCert and ClientKey hold PEM data or the path to a PEM
+	// file, like the provider attributes of the same name.
+	CACert     string
+	ClientCert string
+	ClientKey  string
+	// InsecureSkipVerify disables the verification of the server certificate.
+	InsecureSkipVerify bool
+	// Headers are sent with every request to the Logflare API.
+	Headers map[string]string
+}
+
+var exportLabelInvalidChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// Export walks the Logflare account and writes Terraform configuration with
+// matching import blocks for every object that this provider can manage:
+// sources, endpoints, backends and rules. Teams are left out.
+func Export(ctx context.Context, config ExportConfig) error {
+	host := config.Host
+	if host == "" {
+		host = hostFromEnv()
+	}
+	host, err := normalizeHost(host)
+	if err != nil {
+		return fmt.Errorf("invalid Logflare host: %w", err)
+	}
+
+	if config.AccessToken == "" {
+		return errors.New("missing Logflare access token")
+	}
+
+	for name, value := range config.Headers {
+		if err := validateHeader(name, value); err != nil {
+			return fmt.Errorf("invalid header %q: %w", name, err)
+		}
+	}
+
+	tlsConfig, diags := tlsConfigFromModel(logflareProviderModel{
+		CACert:             exportOptionalString(config.CACert),
+		ClientCert:         exportOptionalString(config.ClientCert),
+		ClientKey:          exportOptionalString(config.ClientKey),
+		InsecureSkipVerify: types.BoolValue(config.InsecureSkipVerify),
+	})
+	if err := diagsError(diags); err != nil {
+		return fmt.Errorf("invalid TLS configuration: %w", err)
+	}
+
+	logf := func(format string, args ...any) {
+		if config.Log != nil {
+			fmt.Fprintf(config.Log, format+"\n", args...)
+		}
+	}
+
+	if config.InsecureSkipVerify {
+		logf("Warning: the Logflare API server certificate is not verified")
+	}
+
+	client, err := newLogflareClient(clientConfig{
+		host:        host,
+		accessToken: config.AccessToken,
+		maxRetries:  defaultMaxRetries,
+		maxBackoff:  defaultMaxBackoff,
+		userAgent:   userAgent(config.Version, ""),
+		headers:     config.Headers,
+		tlsConfig:   tlsConfig,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to create Logflare API client: %w", err)
+	}
+
+	backendsResp, err := client.LogflareWebApiBackendControllerIndexWithResponse(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to list backends: %w", err)
+	}
+	if backendsResp.JSON200 == nil {
+		return fmt.Errorf("unable to list backends, got status %d: %s", backendsResp.StatusCode(), backendsResp.Body)
+	}
+
+	sourcesResp, err := client.LogflareWebApiSourceControllerIndexWithResponse(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to list sources: %w", err)
+	}
+	if sourcesResp.JSON200 == nil {
+		return fmt.Errorf("unable to list sources, got status %d: %s", sourcesResp.StatusCode(), sourcesResp.Body)
+	}
+
+	endpointsResp, err := client.LogflareWebApiEndpointControllerIndexWithResponse(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to list endpoints: %w", err)
+	}
+	if endpointsResp.JSON200 == nil {
+		return fmt.Errorf("unable to list endpoints, got status %d: %s", endpointsResp.StatusCode(), endpointsResp.Body)
+	}
+
+	rulesResp, err := client.LogflareWebApiRuleControllerIndexWithResponse(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to list rules: %w", err)
+	}
+	if rulesResp.JSON200 == nil {
+		return fmt.Errorf("unable to list rules, got status %d: %s", rulesResp.StatusCode(), rulesResp.Body)
+	}
+
+	exporter := newExporter()
+
+	backends, err := exporter.exportBackends(ctx, *backendsResp.JSON200)
+	if err != nil {
+		return err
+	}
+
+	sources, err := exporter.exportSources(ctx, *sourcesResp.JSON200)
+	if err != nil {
+		return err
+	}
+
+	endpoints, err := exporter.exportEndpoints(ctx, *endpointsResp.JSON200)
+	if err != nil {
+		return err
+	}
+
+	rules, err := exporter.exportRules(ctx, *rulesResp.JSON200)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(config.OutputDir, 0o755); err != nil {
+		return err
+	}
+	for name, file := range map[string]*hclwrite.File{
+		"backends.tf":  backends,
+		"sources.tf":   sources,
+		"endpoints.tf": endpoints,
+		"rules.tf":     rules,
+	} {
+		// backends.tf holds the backend configuration, including credentials.
+		perm := os.FileMode(0o644)
+		if name == "backends.tf" {
+			perm = 0o600
+		}
+		if err := os.WriteFile(filepath.Join(config.OutputDir, name), file.Bytes(), perm); err != nil {
+			return err
+		}
+	}
+
+	logf("Exported %d backends, %d sources, %d endpoints and %d rules to %s",
+		len(*backendsResp.JSON200), len(*sourcesResp.JSON200), len(*endpointsResp.JSON200), len(*rulesResp.JSON200), config.OutputDir)
+	if len(*backendsResp.JSON200) > 0 {
+		logf("Warning: backends.tf holds the configuration of the backends, including their credentials; replace them with variables before committing it")
+	}
+	for _, name := range exporter.backendsWithoutConfig {
+		logf("Warning: the server did not return the config of backend %q; set it in backends.tf before planning", name)
+	}
+	if skipped := exportTeamCount(ctx, client); skipped != "" {
+		logf("Warning: %s", skipped)
+	}
+
+	return nil
+}
+
+// exportTeamCount reports the teams of the account, which have no resource
+// type in this provider and are therefore left out of the export.
+func exportTeamCount(ctx context.Context, client *api.ClientWithResponses) string {
+	resp, err := client.LogflareWebApiTeamControllerIndexWithResponse(ctx)
+	switch {
+	case err != nil:
+		return fmt.Sprintf("unable to list teams: %s", err)
+	case resp.JSON200 == nil:
+		return fmt.Sprintf("unable to list teams, got status %d", resp.StatusCode())
+	case len(*resp.JSON200) > 0:
+		return fmt.Sprintf("skipped %d teams, which this provider does not manage", len(*resp.JSON200))
+	}
+	return ""
+}
+
+func exportOptionalString(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
+
+// exporter keeps track of the resource labels and the references to sources
+// and backends handed out while generating configuration.
+type exporter struct {
+	labels        map[string]bool
+	sourceTokens  map[string]hcl.Traversal
+	sourceLabels  map[int]string
+	backendTokens map[string]hcl.Traversal
+	backendIds    map[int]hcl.Traversal
+
+	// backendsWithoutConfig are the names of the backends exported without
+	// their config.
+	backendsWithoutConfig []string
+}
+
+func newExporter() *exporter {
+	return &exporter{
+		labels:        map[string]bool{},
+		sourceTokens:  map[string]hcl.Traversal{},
+		sourceLabels:  map[int]string{},
+		backendTokens: map[string]hcl.Traversal{},
+		backendIds:    map[int]hcl.Traversal{},
+	}
+}
+
+func (e *exporter) exportBackends(ctx context.Context, backends []api.BackendApiSchema) (*hclwrite.File, error) {
+	file := hclwrite.NewEmptyFile()
+	body := file.Body()
+
+	slices.SortFunc(backends, func(a, b api.BackendApiSchema) int { return strings.Compare(a.Name, b.Name) })
+
+	for _, backend := range backends {
+		if backend.Token == nil {
+			continue
+		}
+
+		data := BackendResourceModel{Timeouts: nullTimeouts()}
+		if err := diagsError(backendApiSchemaToModel(&backend, &data)); err != nil {
+			return nil, fmt.Errorf("unable to export backend %q: %w", backend.Name, err)
+		}
+		if data.Config.IsNull() {
+			e.backendsWithoutConfig = append(e.backendsWithoutConfig, backend.Name)
+		}
+
+		label := e.label("logflare_backend", backend.Name)
+		if err := writeExportResource(ctx, body, &BackendResource{}, label, &data, nil); err != nil {
+			return nil, fmt.Errorf("unable to export backend %q: %w", backend.Name, err)
+		}
+		writeExportImport(body, "logflare_backend", label, *backend.Token)
+
+		e.backendTokens[*backend.Token] = exportTraversal("logflare_backend", label, "token")
+		if backend.Id != nil {
+			e.backendIds[*backend.Id] = exportTraversal("logflare_backend", label, "id")
+		}
+	}
+
+	return file, nil
+}
+
+func (e *exporter) exportRules(ctx context.Context, rules []api.RuleApiSchema) (*hclwrite.File, error) {
+	file := hclwrite.NewEmptyFile()
+	body := file.Body()
+
+	slices.SortFunc(rules, func(a, b api.RuleApiSchema) int {
+		return strings.Compare(types.StringPointerValue(a.Token).ValueString(), types.StringPointerValue(b.Token).ValueString())
+	})
+
+	for _, rule := range rules {
+		if rule.Token == nil {
+			continue
+		}
+
+		data := RuleResourceModel{Timeouts: nullTimeouts()}
+		ruleApiSchemaToModel(&rule, &data)
+
+		overrides := map[string]hclwrite.Tokens{}
+		if rule.SourceId != nil && e.sourceLabels[*rule.SourceId] != "" {
+			overrides["source_id"] = hclwrite.TokensForTraversal(exportTraversal("logflare_source", e.sourceLabels[*rule.SourceId], "id"))
+		}
+		if rule.BackendId != nil {
+			if traversal, ok := e.backendIds[*rule.BackendId]; ok {
+				overrides["backend_id"] = hclwrite.TokensForTraversal(traversal)
+			}
+		}
+
+		// Rules have no name, so they are labelled after their source.
+		name := "rule"
+		if rule.SourceId != nil && e.sourceLabels[*rule.SourceId] != "" {
+			name = e.sourceLabels[*rule.SourceId]
+		}
+		label := e.label("logflare_rule", name)
+		if err := writeExportResource(ctx, body, &RuleResource{}, label, &data, overrides); err != nil {
+			return nil, fmt.Errorf("unable to export rule %q: %w", *rule.Token, err)
+		}
+		writeExportImport(body, "logflare_rule", label, *rule.Token)
+	}
+
+	return file, nil
+}
+
+// backendTokensTokens renders the backends attached to a source, replacing
+// their tokens with references to the exported backends. It returns nil when
+// no backend is attached.
+func (e *exporter) backendTokensTokens(tokens []string) hclwrite.Tokens {
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	slices.Sort(tokens)
+	elems := make([]hclwrite.Tokens, 0, len(tokens))
+	for _, token := range tokens {
+		if traversal, ok := e.backendTokens[token]; ok {
+			elems = append(elems, hclwrite.TokensForTraversal(traversal))
+		} else {
+			elems = append(elems, hclwrite.TokensForValue(cty.StringVal(token)))
+		}
+	}
+
+	return hclwrite.TokensForTuple(elems)
+}
+
+func exportTraversal(typeName string, label string, attribute string) hcl.Traversal {
+	return hcl.Traversal{
+		hcl.TraverseRoot{Name: typeName},
+		hcl.TraverseAttr{Name: label},
+		hcl.TraverseAttr{Name: attribute},
+	}
+}
+
+func (e *exporter) exportSources(ctx context.Context, sources []api.Source) (*hclwrite.File, error) {
+	file := hclwrite.NewEmptyFile()
+	body := file.Body()
+
+	slices.SortFunc(sources, func(a, b api.Source) int { return strings.Compare(a.Name, b.Name) })
+
+	for _, source := range sources {
+		if source.Token == nil {
+			continue
+		}
+
+		data := SourceResourceModel{Timeouts: nullTimeouts()}
+		if err := diagsError(sourceSchemaToModel(ctx, &source, &data)); err != nil {
+			return nil, fmt.Errorf("unable to export source %q: %w", source.Name, err)
+		}
+
+		label := e.label("logflare_source", source.Name)
+		overrides := map[string]hclwrite.Tokens{}
+		if tokens := e.backendTokensTokens(sourceBackendTokens(&source)); tokens != nil {
+			overrides["backend_tokens"] = tokens
+		}
+		if err := writeExportResource(ctx, body, &SourceResource{}, label, &data, overrides); err != nil {
+			return nil, fmt.Errorf("unable to export source %q: %w", source.Name, err)
+		}
+		writeExportImport(body, "logflare_source", label, *source.Token)
+
+		e.sourceTokens[*source.Token] = exportTraversal("logflare_source", label, "token")
+		if source.Id != nil {
+			e.sourceLabels[*source.Id] = label
+		}
+	}
+
+	return file, nil
+}
+
+func (e *exporter) exportEndpoints(ctx context.Context, endpoints []api.EndpointApiSchema) (*hclwrite.File, error) {
+	file := hclwrite.NewEmptyFile()
+	body := file.Body()
+
+	slices.SortFunc(endpoints, func(a, b api.EndpointApiSchema) int { return strings.Compare(a.Name, b.Name) })
+
+	for _, endpoint := range endpoints {
+		if endpoint.Token == nil {
+			continue
+		}
+
+		data := EndpointResourceModel{Timeouts: nullTimeouts()}
+		if err := diagsError(endpointApiSchemaToModel(&endpoint, &data)); err != nil {
+			return nil, fmt.Errorf("unable to export endpoint %q: %w", endpoint.Name, err)
+		}
+
+		label := e.label("logflare_endpoint", endpoint.Name)
+		overrides := map[string]hclwrite.Tokens{
+			"source_mapping": e.sourceMappingTokens(endpoint.SourceMapping),
+		}
+		if err := writeExportResource(ctx, body, &EndpointResource{}, label, &data, overrides); err != nil {
+			return nil, fmt.Errorf("unable to export endpoint %q: %w", endpoint.Name, err)
+		}
+		writeExportImport(body, "logflare_endpoint", label, *endpoint.Token)
+	}
+
+	return file, nil
+}
+
+// sourceMappingTokens renders an endpoint source mapping as a jsonencode call,
+// replacing source tokens with references to the exported sources.
+func (e *exporter) sourceMappingTokens(mapping *map[string]any) hclwrite.Tokens {
+	if mapping == nil || len(*mapping) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(*mapping))
+	for key := range *mapping {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	attrs := make([]hclwrite.ObjectAttrTokens, 0, len(keys))
+	for _, key := range keys {
+		var name hclwrite.Tokens
+		if hclsyntax.ValidIdentifier(key) {
+			name = hclwrite.TokensForIdentifier(key)
+		} else {
+			name = hclwrite.TokensForValue(cty.StringVal(key))
+		}
+
+		var value hclwrite.Tokens
+		token, _ := (*mapping)[key].(string)
+		if traversal, ok := e.sourceTokens[token]; ok {
+			value = hclwrite.TokensForTraversal(traversal)
+		} else {
+			b, _ := json.Marshal((*mapping)[key])
+			value = hclwrite.TokensForFunctionCall("jsondecode", hclwrite.TokensForValue(cty.StringVal(string(b))))
+		}
+
+		attrs = append(attrs, hclwrite.ObjectAttrTokens{Name: name, Value: value})
+	}
+
+	return hclwrite.TokensForFunctionCall("jsonencode", hclwrite.TokensForObject(attrs))
+}
+
+// label returns a unique resource label derived from the object name.
+func (e *exporter) label(typeName string, name string) string {
+	base := exportLabelInvalidChars.ReplaceAllString(strings.ToLower(name), "_")
+	base = strings.Trim(base, "_")
+	if base == "" || !hclsyntax.ValidIdentifier(base) || (base[0] >= '0' && base[0] <= '9') {
+		base = "_" + base
+	}
+
+	label := base
+	for i := 2; e.labels[typeName+"."+label]; i++ {
+		label = fmt.Sprintf("%s_%d", base, i)
+	}
+	e.labels[typeName+"."+label] = true
+
+	return label
+}
+
+// writeExportResource appends a resource block with all configurable
+// attributes of the model. Overrides replace the rendered expression of an
+// attribute, and a nil override omits the attribute.
+func writeExportResource(ctx context.Context, body *hclwrite.Body, r resource.Resource, label string, model any, overrides map[string]hclwrite.Tokens) error {
+	var metadataResp resource.MetadataResponse
+	r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "logflare"}, &metadataResp)
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	if err := diagsError(schemaResp.Diagnostics); err != nil {
+		return err
+	}
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	if err := diagsError(state.Set(ctx, model)); err != nil {
+		return err
+	}
+
+	var values map[string]tftypes.Value
+	if err := state.Raw.As(&values); err != nil {
+		return err
+	}
+
+	var required, optional []string
+	for name, attribute := range schemaResp.Schema.Attributes {
+		switch {
+		case attribute.IsRequired():
+			required = append(required, name)
+		case attribute.IsOptional():
+			optional = append(optional, name)
+		}
+	}
+	slices.Sort(required)
+	slices.Sort(optional)
+
+	block := body.AppendNewBlock("resource", []string{metadataResp.TypeName, label}).Body()
+	for _, name := range append(required, optional...) {
+		if tokens, ok := overrides[name]; ok {
+			if tokens != nil {
+				block.SetAttributeRaw(name, tokens)
+			}
+			continue
+		}
+
+		if values[name].IsNull() {
+			continue
+		}
+
+		value, err := tftypesValueToCty(values[name])
+		if err != nil {
+			return fmt.Errorf("attribute %q: %w", name, err)
+		}
+		block.SetAttributeValue(name, value)
+	}
+	body.AppendNewline()
+
+	return nil
+}
+
+func writeExportImport(body *hclwrite.Body, typeName string, label string, token string) {
+	block := body.AppendNewBlock("import", nil).Body()
+	block.SetAttributeTraversal("to", hcl.Traversal{
+		hcl.TraverseRoot{Name: typeName},
+		hcl.TraverseAttr{Name: label},
+	})
+	block.SetAttributeValue("id", cty.StringVal(token))
+	body.AppendNewline()
+}
+
+// tftypesValueToCty converts a Terraform value into the equivalent cty value
+// so it can be rendered as HCL.
+func tftypesValueToCty(v tftypes.Value) (cty.Value, error) {
+	if v.IsNull() {
+		return cty.NullVal(cty.DynamicPseudoType), nil
+	}
+
+	switch typ := v.Type(); {
+	case typ.Is(tftypes.String):
+		var s string
+		if err := v.As(&s); err != nil {
+			return cty.NilVal, err
+		}
+		return cty.StringVal(s), nil
+	case typ.Is(tftypes.Number):
+		n := new(big.Float)
+		if err := v.As(&n); err != nil {
+			return cty.NilVal, err
+		}
+		return cty.NumberVal(n), nil
+	case typ.Is(tftypes.Bool):
+		var b bool
+		if err := v.As(&b); err != nil {
+			return cty.NilVal, err
+		}
+		return cty.BoolVal(b), nil
+	case typ.Is(tftypes.List{}), typ.Is(tftypes.Set{}), typ.Is(tftypes.Tuple{}):
+		var elems []tftypes.Value
+		if err := v.As(&elems); err != nil {
+			return cty.NilVal, err
+		}
+		if len(elems) == 0 {
+			return cty.EmptyTupleVal, nil
+		}
+		values := make([]cty.Value, 0, len(elems))
+		for _, elem := range elems {
+			value, err := tftypesValueToCty(elem)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			values = append(values, value)
+		}
+		return cty.TupleVal(values), nil
+	case typ.Is(tftypes.Map{}), typ.Is(tftypes.Object{}):
+		var attrs map[string]tftypes.Value
+		if err := v.As(&attrs); err != nil {
+			return cty.NilVal, err
+		}
+		if len(attrs) == 0 {
+			return cty.EmptyObjectVal, nil
+		}
+		values := make(map[string]cty.Value, len(attrs))
+		for name, attr := range attrs {
+			value, err := tftypesValueToCty(attr)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			values[name] = value
+		}
+		return cty.ObjectVal(values), nil
+	default:
+		return cty.NilVal, fmt.Errorf("unsupported value type %s", typ)
+	}
+}
+
+// diagsError turns error diagnostics into a Go error.
+func diagsError(diags diag.Diagnostics) error {
+	var errs []error
+	for _, d := range diags.Errors() {
+		errs = append(errs, fmt.Errorf("%s: %s", d.Summary(), d.Detail()))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,130 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExport(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Tenant"); got != "acme" {
+			t.Errorf("expected the X-Tenant header to be sent, got %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/sources":
+			_, _ = io.WriteString(w, `[{"id": 1, "name": "my-cool-source", "token": "source-token", "api_quota": 25, "favorite": true, "metrics": {"avg": 1}, "backends": [{"token": "backend-token"}]}]`)
+		case "/api/endpoints":
+			_, _ = io.WriteString(w, `[{"id": 2, "name": "my_cool_endpoint", "token": "endpoint-token", "query": "select id from my_source", "source_mapping": {"my_source": "source-token"}}]`)
+		case "/api/backends":
+			_, _ = io.WriteString(w, `[{"id": 3, "name": "my-cool-webhook", "token": "backend-token", "type": "webhook", "config": {"url": "https://example.com"}}]`)
+		case "/api/rules":
+			_, _ = io.WriteString(w, `[{"id": 4, "token": "rule-token", "source_id": 1, "backend_id": 3, "lql_string": "m.status_code:>499"}]`)
+		default:
+			_, _ = io.WriteString(w, `[]`)
+		}
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	err := Export(context.Background(), ExportConfig{
+		Host:        server.URL,
+		AccessToken: "my-cool-api-key-123",
+		OutputDir:   dir,
+		CACert:      string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})),
+		Headers:     map[string]string{"X-Tenant": "acme"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sources, err := os.ReadFile(filepath.Join(dir, "sources.tf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`resource "logflare_source" "my_cool_source" {`,
+		`name           = "my-cool-source"`,
+		`favorite       = true`,
+		`to = logflare_source.my_cool_source`,
+		`id = "source-token"`,
+		`backend_tokens = [logflare_backend.my_cool_webhook.token]`,
+	} {
+		if !strings.Contains(string(sources), want) {
+			t.Errorf("sources.tf does not contain %q:\n%s", want, sources)
+		}
+	}
+	if strings.Contains(string(sources), "metrics") {
+		t.Errorf("sources.tf should not contain metrics:\n%s", sources)
+	}
+
+	endpoints, err := os.ReadFile(filepath.Join(dir, "endpoints.tf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`resource "logflare_endpoint" "my_cool_endpoint" {`,
+		`my_source = logflare_source.my_cool_source.token`,
+		`to = logflare_endpoint.my_cool_endpoint`,
+	} {
+		if !strings.Contains(string(endpoints), want) {
+			t.Errorf("endpoints.tf does not contain %q:\n%s", want, endpoints)
+		}
+	}
+
+	backends, err := os.ReadFile(filepath.Join(dir, "backends.tf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`resource "logflare_backend" "my_cool_webhook" {`,
+		`type   = "webhook"`,
+		`to = logflare_backend.my_cool_webhook`,
+	} {
+		if !strings.Contains(string(backends), want) {
+			t.Errorf("backends.tf does not contain %q:\n%s", want, backends)
+		}
+	}
+
+	rules, err := os.ReadFile(filepath.Join(dir, "rules.tf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`resource "logflare_rule" "my_cool_source" {`,
+		`backend_id = logflare_backend.my_cool_webhook.id`,
+		`source_id  = logflare_source.my_cool_source.id`,
+		`to = logflare_rule.my_cool_source`,
+		`id = "rule-token"`,
+	} {
+		if !strings.Contains(string(rules), want) {
+			t.Errorf("rules.tf does not contain %q:\n%s", want, rules)
+		}
+	}
+}
+
+func TestExportInvalidOptions(t *testing.T) {
+	for name, config := range map[string]ExportConfig{
+		"header":      {Headers: map[string]string{"Authorization": "Bearer other"}},
+		"client cert": {ClientCert: "-----BEGIN CERTIFICATE-----"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			config.Host = "https://logflare.example"
+			config.AccessToken = "my-cool-api-key-123"
+			config.OutputDir = t.TempDir()
+			if err := Export(context.Background(), config); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
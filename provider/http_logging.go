@@ -0,0 +1,227 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// httpLogSubsystem is the tflog subsystem of the API traces. Its level is
+	// controlled with httpLogLevelEnvVar.
+	httpLogSubsystem = "http"
+
+	// httpLogLevelEnvVar sets the level of the http subsystem. Tracing is off
+	// unless it is DEBUG or TRACE.
+	httpLogLevelEnvVar = "TF_LOG_PROVIDER_LOGFLARE_HTTP"
+
+	// httpLogMaxBody is the number of body bytes included in a trace.
+	httpLogMaxBody = 16 * 1024
+
+	redacted = "***"
+)
+
+var (
+	// uuidPattern matches Logflare source, endpoint and backend tokens.
+	uuidPattern = regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`)
+
+	// accessTokenPathPattern matches the access token in access token paths,
+	// which is not a UUID.
+	accessTokenPathPattern = regexp.MustCompile(`(/api/access-tokens/)[^/?]+`)
+
+	// sensitiveBodyKeys are the JSON keys whose values are redacted from
+	// request and response bodies.
+	sensitiveBodyKeys = map[string]bool{
+		"token":                    true,
+		"public_token":             true,
+		"source_token":             true,
+		"access_token":             true,
+		"api_key":                  true,
+		"url":                      true,
+		"slack_hook_url":           true,
+		"webhook_notification_url": true,
+	}
+)
+
+// loggingTransport traces Logflare API requests and responses with secrets
+// redacted.
+type loggingTransport struct {
+	next  http.RoundTripper
+	level hclog.Level
+}
+
+func newLoggingTransport(base http.RoundTripper) http.RoundTripper {
+	return &loggingTransport{
+		next:  base,
+		level: hclog.LevelFromString(os.Getenv(httpLogLevelEnvVar)),
+	}
+}
+
+// enabled reports whether the traces are logged at all. Tracing buffers and
+// redacts every body, which is only worth it when someone reads the result.
+func (t *loggingTransport) enabled() bool {
+	return t.level != hclog.NoLevel && t.level <= hclog.Debug
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.enabled() {
+		return t.next.RoundTrip(req)
+	}
+
+	ctx := tflog.NewSubsystem(req.Context(), httpLogSubsystem, tflog.WithLevel(t.level))
+
+	req, reqBody, err := peekRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	tflog.SubsystemDebug(ctx, httpLogSubsystem, "Sending Logflare API request", map[string]any{
+		"method":          req.Method,
+		"url":             redactURL(req.URL.String()),
+		"request_headers": redactHeaders(req.Header),
+		"request_body":    redactBody(reqBody),
+	})
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	latency := time.Since(start)
+
+	if err != nil {
+		tflog.SubsystemDebug(ctx, httpLogSubsystem, "Logflare API request failed", map[string]any{
+			"method":  req.Method,
+			"url":     redactURL(req.URL.String()),
+			"latency": latency.String(),
+			"error":   err.Error(),
+		})
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	tflog.SubsystemDebug(ctx, httpLogSubsystem, "Received Logflare API response", map[string]any{
+		"method":           req.Method,
+		"url":              redactURL(req.URL.String()),
+		"status":           resp.StatusCode,
+		"latency":          latency.String(),
+		"response_headers": redactHeaders(resp.Header),
+		"response_body":    redactBody(respBody),
+	})
+
+	return resp, nil
+}
+
+// peekRequestBody returns the body of req together with a request that can
+// still be sent. The original request is left untouched.
+func peekRequestBody(req *http.Request) (*http.Request, []byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return req, nil, nil
+	}
+
+	if req.GetBody != nil {
+		rc, err := req.GetBody()
+		if err != nil {
+			return nil, nil, err
+		}
+		defer rc.Close()
+
+		body, err := io.ReadAll(rc)
+		return req, body, err
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clone := req.Clone(req.Context())
+	clone.Body = io.NopCloser(bytes.NewReader(body))
+
+	return clone, body, nil
+}
+
+func redactURL(url string) string {
+	url = accessTokenPathPattern.ReplaceAllString(url, "${1}"+redacted)
+	return uuidPattern.ReplaceAllString(url, redacted)
+}
+
+func redactHeaders(header http.Header) map[string]string {
+	headers := make(map[string]string, len(header))
+	for name, values := range header {
+		value := strings.Join(values, ", ")
+		switch http.CanonicalHeaderKey(name) {
+		case "Authorization", "Cookie", "Set-Cookie":
+			value = redacted
+		}
+		headers[name] = value
+	}
+	return headers
+}
+
+// redactBody masks sensitive values in a JSON body. Bodies that are not
+// JSON only have tokens masked.
+func redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var value any
+	if err := json.Unmarshal(body, &value); err == nil {
+		if redactedBody, err := json.Marshal(redactJSONValue(value)); err == nil {
+			body = redactedBody
+		}
+	}
+
+	s := uuidPattern.ReplaceAllString(string(body), redacted)
+	if len(s) > httpLogMaxBody {
+		s = s[:httpLogMaxBody] + "...(truncated)"
+	}
+	return s
+}
+
+func redactJSONValue(value any) any {
+	switch value := value.(type) {
+	case map[string]any:
+		for key, v := range value {
+			switch {
+			case sensitiveBodyKeys[key]:
+				if v != nil {
+					value[key] = redacted
+				}
+			case key == "source_mapping":
+				// Source mappings map query names to source tokens.
+				if mapping, ok := v.(map[string]any); ok {
+					for name := range mapping {
+						mapping[name] = redacted
+					}
+				}
+			default:
+				value[key] = redactJSONValue(v)
+			}
+		}
+		return value
+	case []any:
+		for i, v := range value {
+			value[i] = redactJSONValue(v)
+		}
+		return value
+	default:
+		return value
+	}
+}
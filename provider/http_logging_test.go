@@ -0,0 +1,151 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestRedactURL(t *testing.T) {
+	testCases := map[string]string{
+		"http://localhost:4000/api/sources/b1b4b1c9-1c1e-4d5c-8f2e-2f8c1e5a9d3b":     "http://localhost:4000/api/sources/***",
+		"http://localhost:4000/api/logs?source=b1b4b1c9-1c1e-4d5c-8f2e-2f8c1e5a9d3b": "http://localhost:4000/api/logs?source=***",
+		"http://localhost:4000/api/access-tokens/abcdef123":                          "http://localhost:4000/api/access-tokens/***",
+		"http://localhost:4000/api/endpoints/query/name/my_endpoint?sql=select+1":    "http://localhost:4000/api/endpoints/query/name/my_endpoint?sql=select+1",
+	}
+	for url, want := range testCases {
+		if got := redactURL(url); got != want {
+			t.Errorf("redactURL(%q) = %q, want %q", url, got, want)
+		}
+	}
+}
+
+func TestRedactHeaders(t *testing.T) {
+	got := redactHeaders(http.Header{
+		"Authorization": {"Bearer my-cool-api-key-123"},
+		"Content-Type":  {"application/json"},
+	})
+	if got["Authorization"] != redacted {
+		t.Errorf("Authorization header was not redacted: %q", got["Authorization"])
+	}
+	if got["Content-Type"] != "application/json" {
+		t.Errorf("Content-Type header = %q", got["Content-Type"])
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	body := `{
+		"name": "my-source",
+		"token": "source-token",
+		"public_token": "public-token",
+		"webhook_notification_url": "https://hooks.example.com/secret",
+		"source_mapping": {"my_source": "mapped-token"},
+		"nested": [{"token": "nested-token", "id": 1}],
+		"description": "b1b4b1c9-1c1e-4d5c-8f2e-2f8c1e5a9d3b"
+	}`
+
+	got := redactBody([]byte(body))
+	for _, secret := range []string{"source-token", "public-token", "hooks.example.com", "mapped-token", "nested-token", "b1b4b1c9"} {
+		if strings.Contains(got, secret) {
+			t.Errorf("redacted body contains %q: %s", secret, got)
+		}
+	}
+	for _, kept := range []string{`"name":"my-source"`, `"id":1`} {
+		if !strings.Contains(got, kept) {
+			t.Errorf("redacted body does not contain %q: %s", kept, got)
+		}
+	}
+
+	if got := redactBody([]byte("not json b1b4b1c9-1c1e-4d5c-8f2e-2f8c1e5a9d3b")); got != "not json ***" {
+		t.Errorf("redactBody of plain text = %q", got)
+	}
+}
+
+func TestLoggingTransport(t *testing.T) {
+	t.Setenv(httpLogLevelEnvVar, "DEBUG")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"name":"my-source"}` {
+			t.Errorf("server received body %q", body)
+		}
+		_, _ = io.WriteString(w, `{"name": "my-source", "token": "source-token"}`)
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/api/sources", strings.NewReader(`{"name":"my-source"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer my-cool-api-key-123")
+
+	resp, err := (&http.Client{Transport: newLoggingTransport(http.DefaultTransport)}).Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(body), "source-token") {
+		t.Errorf("response body was not passed through: %s", body)
+	}
+
+	logs := output.String()
+	for _, want := range []string{"Sending Logflare API request", "Received Logflare API response", `.http"`} {
+		if !strings.Contains(logs, want) {
+			t.Errorf("logs do not contain %q:\n%s", want, logs)
+		}
+	}
+	for _, secret := range []string{"my-cool-api-key-123", "source-token"} {
+		if strings.Contains(logs, secret) {
+			t.Errorf("logs contain %q:\n%s", secret, logs)
+		}
+	}
+}
+
+func TestLoggingTransportDisabled(t *testing.T) {
+	t.Setenv(httpLogLevelEnvVar, "")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"name": "my-source"}`)
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/api/sources", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transport := newLoggingTransport(http.DefaultTransport)
+	if transport.(*loggingTransport).enabled() {
+		t.Fatal("expected tracing to be disabled")
+	}
+
+	resp, err := (&http.Client{Transport: transport}).Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if output.Len() != 0 {
+		t.Errorf("expected no logs, got:\n%s", output.String())
+	}
+}
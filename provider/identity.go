@@ -0,0 +1,26 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// tokenIdentityModel describes the identity of resources that are addressed
+// by their token in the Logflare API.
+type tokenIdentityModel struct {
+	Token types.String `tfsdk:"token"`
+}
+
+func tokenIdentitySchema() identityschema.Schema {
+	return identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"token": identityschema.StringAttribute{
+				Description:       "Token of the object in the Logflare API.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
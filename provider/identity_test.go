@@ -0,0 +1,122 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// emptyTestState returns a null state and identity for the schemas of r.
+func emptyTestState(t *testing.T, r resource.Resource) (tfsdk.State, *tfsdk.ResourceIdentity) {
+	t.Helper()
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+
+	identitySchema := tokenIdentitySchema()
+	identity := &tfsdk.ResourceIdentity{
+		Schema: identitySchema,
+		Raw:    tftypes.NewValue(identitySchema.Type().TerraformType(ctx), nil),
+	}
+
+	return state, identity
+}
+
+func TestImportStateByIdentity(t *testing.T) {
+	ctx := context.Background()
+
+	for _, r := range []resource.ResourceWithImportState{&SourceResource{}, &EndpointResource{}, &BackendResource{}, &RuleResource{}} {
+		state, identity := emptyTestState(t, r)
+		if diags := identity.Set(ctx, tokenIdentityModel{Token: types.StringValue("imported-token")}); diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+
+		resp := resource.ImportStateResponse{State: state, Identity: identity}
+		r.ImportState(ctx, resource.ImportStateRequest{Identity: identity}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("%T: unexpected diagnostics: %v", r, resp.Diagnostics)
+		}
+
+		var token types.String
+		resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("token"), &token)...)
+		if token.ValueString() != "imported-token" {
+			t.Errorf("%T: expected the token of the identity in state, got %s", r, token)
+		}
+	}
+}
+
+func TestImportStateByID(t *testing.T) {
+	ctx := context.Background()
+
+	for _, r := range []resource.ResourceWithImportState{&SourceResource{}, &EndpointResource{}, &BackendResource{}, &RuleResource{}} {
+		state, identity := emptyTestState(t, r)
+
+		resp := resource.ImportStateResponse{State: state, Identity: identity}
+		r.ImportState(ctx, resource.ImportStateRequest{ID: "imported-token"}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("%T: unexpected diagnostics: %v", r, resp.Diagnostics)
+		}
+
+		var token types.String
+		resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("token"), &token)...)
+		if token.ValueString() != "imported-token" {
+			t.Errorf("%T: expected the imported ID as token, got %s", r, token)
+		}
+	}
+}
+
+func TestEndpointResourceReadSetsIdentity(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/endpoints/endpoint-token" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"id": 7, "name": "errors", "query": "select 1", "token": "endpoint-token"}`)
+	}))
+	defer server.Close()
+
+	client, err := newLogflareClient(clientConfig{host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	r := &EndpointResource{client: client, names: &nameIndex{}}
+
+	state, identity := emptyTestState(t, r)
+	if diags := state.Set(ctx, &EndpointResourceModel{Token: types.StringValue("endpoint-token"), Timeouts: nullTimeouts()}); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	resp := resource.ReadResponse{State: state, Identity: identity}
+	r.Read(ctx, resource.ReadRequest{State: state, Identity: identity}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var got tokenIdentityModel
+	if diags := resp.Identity.Get(ctx, &got); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if got.Token.ValueString() != "endpoint-token" {
+		t.Errorf("expected the identity to hold the token, got %s", got.Token)
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// legacyProviderAddress is the registry address the provider was first
+// published under. State of its resources can be moved to this provider with
+// moved blocks.
+const legacyProviderAddress = "registry.terraform.io/logflare/logflare"
+
+// legacyStateMover moves the state of typeName from the legacy provider
+// address to the resource of the same type in this provider. The legacy
+// state goes through the same upgrade steps as an older state of this
+// provider, and attributes the resource no longer has are dropped.
+func legacyStateMover(typeName string, steps []stateUpgradeStep) resource.StateMover {
+	return resource.StateMover{
+		StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+			// Leave other sources to the remaining movers, or to the error
+			// Terraform reports when no mover handles them.
+			if req.SourceProviderAddress != legacyProviderAddress || req.SourceTypeName != typeName {
+				return
+			}
+
+			if req.SourceRawState == nil || req.SourceRawState.JSON == nil {
+				resp.Diagnostics.AddError(
+					"Unable to Move Resource State",
+					fmt.Sprintf("The state of %s has no JSON data. Please report this issue to the provider developers.", typeName),
+				)
+				return
+			}
+
+			version := int(req.SourceSchemaVersion)
+			if version < 0 {
+				resp.Diagnostics.AddError(
+					"Unable to Move Resource State",
+					fmt.Sprintf("The state of %s has the invalid schema version %d. Please report this issue to the provider developers.", typeName, version),
+				)
+				return
+			}
+			if version > len(steps) {
+				resp.Diagnostics.AddError(
+					"Unable to Move Resource State",
+					fmt.Sprintf("The state of %s has schema version %d, which is newer than the version %d of this provider. "+
+						"Upgrade the provider before moving the resource.", typeName, version, len(steps)),
+				)
+				return
+			}
+
+			state, diags := applyStateUpgradeSteps(req.SourceRawState.JSON, version, steps[version:])
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			targetType := resp.TargetState.Schema.Type().TerraformType(ctx)
+			dropUnknownStateKeys(state, targetType)
+
+			moved, err := json.Marshal(state)
+			if err != nil {
+				resp.Diagnostics.AddError("Unable to Move Resource State", err.Error())
+				return
+			}
+
+			rawState := tfprotov6.RawState{JSON: moved}
+			value, err := rawState.Unmarshal(targetType)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Unable to Move Resource State",
+					fmt.Sprintf("Unable to decode the state of %s: %s", typeName, err),
+				)
+				return
+			}
+			resp.TargetState.Raw = value
+
+			if resp.TargetIdentity != nil {
+				var token types.String
+				resp.Diagnostics.Append(resp.TargetState.GetAttribute(ctx, path.Root("token"), &token)...)
+				resp.Diagnostics.Append(resp.TargetIdentity.Set(ctx, tokenIdentityModel{Token: token})...)
+			}
+		},
+	}
+}
+
+// sourceBackendStateMover moves the state of a logflare_source_backend
+// attachment from the legacy provider address onto a logflare_source that
+// manages its attachments inline in backend_tokens. Only the source token and
+// the attached backend are known from the attachment, so the rest of the
+// source, including any other attached backends, is filled in by the refresh
+// that follows the move.
+func sourceBackendStateMover() resource.StateMover {
+	return resource.StateMover{
+		StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+			if req.SourceProviderAddress != legacyProviderAddress || req.SourceTypeName != "logflare_source_backend" {
+				return
+			}
+
+			if req.SourceRawState == nil || req.SourceRawState.JSON == nil {
+				resp.Diagnostics.AddError(
+					"Unable to Move Resource State",
+					"The state of logflare_source_backend has no JSON data. Please report this issue to the provider developers.",
+				)
+				return
+			}
+
+			var attachment struct {
+				SourceToken  string `json:"source_token"`
+				BackendToken string `json:"backend_token"`
+			}
+			if err := json.Unmarshal(req.SourceRawState.JSON, &attachment); err != nil {
+				resp.Diagnostics.AddError(
+					"Unable to Move Resource State",
+					fmt.Sprintf("Unable to decode the state of logflare_source_backend: %s", err),
+				)
+				return
+			}
+			if attachment.SourceToken == "" || attachment.BackendToken == "" {
+				resp.Diagnostics.AddError(
+					"Unable to Move Resource State",
+					"The state of logflare_source_backend has no source_token or backend_token, so the source it attaches to is unknown.",
+				)
+				return
+			}
+
+			// Attributes left out of the state are read as null.
+			moved, err := json.Marshal(map[string]any{
+				"backend_tokens": []string{attachment.BackendToken},
+				"token":          attachment.SourceToken,
+			})
+			if err != nil {
+				resp.Diagnostics.AddError("Unable to Move Resource State", err.Error())
+				return
+			}
+
+			rawState := tfprotov6.RawState{JSON: moved}
+			value, err := rawState.Unmarshal(resp.TargetState.Schema.Type().TerraformType(ctx))
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Unable to Move Resource State",
+					fmt.Sprintf("Unable to decode the state of logflare_source_backend: %s", err),
+				)
+				return
+			}
+			resp.TargetState.Raw = value
+
+			if resp.TargetIdentity != nil {
+				resp.Diagnostics.Append(resp.TargetIdentity.Set(ctx, tokenIdentityModel{Token: types.StringValue(attachment.SourceToken)})...)
+			}
+		},
+	}
+}
@@ -0,0 +1,263 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	testresource "github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// moveTestState runs the state movers of r on req the way the framework
+// does, stopping at the first mover that sets the target state.
+func moveTestState(t *testing.T, r resource.ResourceWithMoveState, req resource.MoveStateRequest) resource.MoveStateResponse {
+	t.Helper()
+	ctx := context.Background()
+
+	state, identity := emptyTestState(t, r)
+	resp := resource.MoveStateResponse{TargetState: state, TargetIdentity: identity}
+
+	for _, mover := range r.MoveState(ctx) {
+		mover.StateMover(ctx, req, &resp)
+		if resp.Diagnostics.HasError() || !resp.TargetState.Raw.IsNull() {
+			break
+		}
+	}
+
+	return resp
+}
+
+func TestSourceResourceMoveStateFromLegacyProvider(t *testing.T) {
+	raw, err := os.ReadFile(filepath.Join("testdata", "state", "source_v0.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := moveTestState(t, &SourceResource{}, resource.MoveStateRequest{
+		SourceProviderAddress: "registry.terraform.io/logflare/logflare",
+		SourceTypeName:        "logflare_source",
+		SourceSchemaVersion:   0,
+		SourceRawState:        &tfprotov6.RawState{JSON: raw},
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var data SourceResourceModel
+	if diags := resp.TargetState.Get(context.Background(), &data); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if data.Token.ValueString() != "source-token" || !data.Metrics.IsNull() {
+		t.Errorf("unexpected moved state %+v", data)
+	}
+
+	var identity tokenIdentityModel
+	if diags := resp.TargetIdentity.Get(context.Background(), &identity); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if identity.Token.ValueString() != "source-token" {
+		t.Errorf("expected the identity to hold the token, got %s", identity.Token)
+	}
+}
+
+func TestEndpointResourceMoveStateFromLegacyProvider(t *testing.T) {
+	raw := []byte(`{"id": 7, "name": "errors", "query": "select 1", "token": "endpoint-token", "legacy_only": "dropped"}`)
+
+	resp := moveTestState(t, &EndpointResource{}, resource.MoveStateRequest{
+		SourceProviderAddress: "registry.terraform.io/logflare/logflare",
+		SourceTypeName:        "logflare_endpoint",
+		SourceRawState:        &tfprotov6.RawState{JSON: raw},
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var data EndpointResourceModel
+	if diags := resp.TargetState.Get(context.Background(), &data); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if data.Id.ValueInt64() != 7 || data.Token.ValueString() != "endpoint-token" {
+		t.Errorf("unexpected moved state %+v", data)
+	}
+}
+
+func TestResourceMoveStateIgnoresOtherSources(t *testing.T) {
+	raw := []byte(`{"id": 7, "token": "endpoint-token"}`)
+
+	for _, req := range []resource.MoveStateRequest{
+		{SourceProviderAddress: "registry.terraform.io/hashicorp/random", SourceTypeName: "logflare_endpoint"},
+		{SourceProviderAddress: "registry.terraform.io/logflare/logflare", SourceTypeName: "logflare_source"},
+	} {
+		req.SourceRawState = &tfprotov6.RawState{JSON: raw}
+		resp := moveTestState(t, &EndpointResource{}, req)
+		if resp.Diagnostics.HasError() || !resp.TargetState.Raw.IsNull() {
+			t.Errorf("expected %s from %s to be left alone, got %v", req.SourceTypeName, req.SourceProviderAddress, resp.Diagnostics)
+		}
+	}
+}
+
+func TestResourceMoveStateNewerVersion(t *testing.T) {
+	resp := moveTestState(t, &EndpointResource{}, resource.MoveStateRequest{
+		SourceProviderAddress: "registry.terraform.io/logflare/logflare",
+		SourceTypeName:        "logflare_endpoint",
+		SourceSchemaVersion:   5,
+		SourceRawState:        &tfprotov6.RawState{JSON: []byte(`{}`)},
+	})
+	if !resp.Diagnostics.HasError() {
+		t.Error("expected an error for a schema version newer than the provider")
+	}
+}
+
+func TestSourceResourceMoveStateDropsNestedKeys(t *testing.T) {
+	raw := []byte(`{
+		"id": 1,
+		"name": "requests",
+		"token": "source-token",
+		"notifications": {"user_email_notifications": true, "legacy_only": "dropped"},
+		"metrics": {"avg": 1, "legacy_only": 2}
+	}`)
+
+	resp := moveTestState(t, &SourceResource{}, resource.MoveStateRequest{
+		SourceProviderAddress: "registry.terraform.io/logflare/logflare",
+		SourceTypeName:        "logflare_source",
+		SourceSchemaVersion:   2,
+		SourceRawState:        &tfprotov6.RawState{JSON: raw},
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var data SourceResourceModel
+	if diags := resp.TargetState.Get(context.Background(), &data); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	var notifications NotificationModel
+	if diags := data.Notifications.As(context.Background(), &notifications, basetypes.ObjectAsOptions{}); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if !notifications.UserEmailNotifications.ValueBool() {
+		t.Errorf("expected the known notification fields to be kept, got %+v", notifications)
+	}
+}
+
+func TestResourceMoveStateNegativeVersion(t *testing.T) {
+	resp := moveTestState(t, &EndpointResource{}, resource.MoveStateRequest{
+		SourceProviderAddress: "registry.terraform.io/logflare/logflare",
+		SourceTypeName:        "logflare_endpoint",
+		SourceSchemaVersion:   -1,
+		SourceRawState:        &tfprotov6.RawState{JSON: []byte(`{}`)},
+	})
+	if !resp.Diagnostics.HasError() || strings.Contains(resp.Diagnostics[0].Detail(), "newer") {
+		t.Errorf("expected an invalid version error, got %v", resp.Diagnostics)
+	}
+}
+
+func TestSourceResourceMoveStateFromSourceBackend(t *testing.T) {
+	raw := []byte(`{"id": "source-token/backend-token", "source_token": "source-token", "backend_token": "backend-token"}`)
+
+	resp := moveTestState(t, &SourceResource{}, resource.MoveStateRequest{
+		SourceProviderAddress: "registry.terraform.io/logflare/logflare",
+		SourceTypeName:        "logflare_source_backend",
+		SourceRawState:        &tfprotov6.RawState{JSON: raw},
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var data SourceResourceModel
+	if diags := resp.TargetState.Get(context.Background(), &data); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	var backendTokens []string
+	if diags := data.BackendTokens.ElementsAs(context.Background(), &backendTokens, false); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if data.Token.ValueString() != "source-token" || len(backendTokens) != 1 || backendTokens[0] != "backend-token" {
+		t.Errorf("unexpected moved state %+v", data)
+	}
+
+	var identity tokenIdentityModel
+	if diags := resp.TargetIdentity.Get(context.Background(), &identity); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if identity.Token.ValueString() != "source-token" {
+		t.Errorf("expected the identity to hold the source token, got %s", identity.Token)
+	}
+}
+
+func TestSourceResourceMoveStateFromSourceBackendWithoutTokens(t *testing.T) {
+	resp := moveTestState(t, &SourceResource{}, resource.MoveStateRequest{
+		SourceProviderAddress: "registry.terraform.io/logflare/logflare",
+		SourceTypeName:        "logflare_source_backend",
+		SourceRawState:        &tfprotov6.RawState{JSON: []byte(`{"source_token": "source-token"}`)},
+	})
+	if !resp.Diagnostics.HasError() {
+		t.Error("expected an error for an attachment without a backend token")
+	}
+}
+
+// TestAccSourceResourceMoveStateFromLegacyProvider creates a source with the
+// provider published under the legacy address and moves it to this provider
+// with a moved block, which must update the source in place.
+func TestAccSourceResourceMoveStateFromLegacyProvider(t *testing.T) {
+	testresource.Test(t, testresource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		Steps: []testresource.TestStep{
+			{
+				ExternalProviders: map[string]testresource.ExternalProvider{
+					"legacy": {Source: "logflare/logflare"},
+				},
+				Config: testAccLegacyProviderConfig + `
+resource "logflare_source" "legacy" {
+	provider = legacy
+	name     = "my-moved-source"
+}
+`,
+			},
+			{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Config: providerConfig + `
+moved {
+	from = logflare_source.legacy
+	to   = logflare_source.moved
+}
+
+resource "logflare_source" "moved" {
+	name                = "my-moved-source"
+	deletion_protection = false
+}
+`,
+				ConfigPlanChecks: testresource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						// Only deletion_protection changes, from its default.
+						plancheck.ExpectResourceAction("logflare_source.moved", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentityValueMatchesState("logflare_source.moved", tfjsonpath.New("token")),
+				},
+			},
+		},
+	})
+}
+
+const testAccLegacyProviderConfig = `
+provider "legacy" {
+  access_token 	= "my-cool-api-key-123"
+  host     		= "http://localhost:4000"
+}
+`
@@ -0,0 +1,160 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/supabase/terraform-provider-supabase-analytics/internal/pkg/api"
+)
+
+// planNameCheck returns the planned name when it needs to be checked for
+// duplicates, because the resource is created or renamed, together with the
+// token of the resource itself.
+func planNameCheck(ctx context.Context, req resource.ModifyPlanRequest) (name string, ownToken string, check bool, diags diag.Diagnostics) {
+	var planned, prior, token types.String
+	diags.Append(req.Plan.GetAttribute(ctx, path.Root("name"), &planned)...)
+	if !req.State.Raw.IsNull() {
+		diags.Append(req.State.GetAttribute(ctx, path.Root("name"), &prior)...)
+		diags.Append(req.State.GetAttribute(ctx, path.Root("token"), &token)...)
+	}
+	if diags.HasError() || planned.IsUnknown() || planned.IsNull() || planned.Equal(prior) {
+		return "", "", false, diags
+	}
+
+	return planned.ValueString(), token.ValueString(), true, diags
+}
+
+// duplicateNameDiagnostic reports that another object of resourceType already
+// uses the planned name.
+func duplicateNameDiagnostic(resourceType string, kind string, name string, token string) diag.Diagnostic {
+	return diag.NewAttributeErrorDiagnostic(
+		path.Root("name"),
+		"Duplicate Name",
+		fmt.Sprintf("A %s named %q already exists with token %s. "+
+			"To manage it with Terraform, import it instead of creating a new one, for example with an import block "+
+			"whose to is the %s resource and whose id is %q. Otherwise choose another name.",
+			kind, name, token, resourceType, token),
+	)
+}
+
+// nameNotCheckedDiagnostic is a warning for when the index could not be read,
+// as the server still rejects duplicates on apply.
+func nameNotCheckedDiagnostic(kind string, err string) diag.Diagnostic {
+	return diag.NewAttributeWarningDiagnostic(
+		path.Root("name"),
+		"Name Not Checked",
+		fmt.Sprintf("Unable to list %ss to check that the name is unused: %s", kind, err),
+	)
+}
+
+// nameIndex caches, per provider instance, the names of existing sources and
+// endpoints, so that planning many resources lists each kind only once.
+type nameIndex struct {
+	mu      sync.Mutex
+	entries map[string]nameIndexEntry
+}
+
+// nameIndexEntry is the cached index of one kind. err is set when the index
+// could not be read.
+type nameIndexEntry struct {
+	tokens map[string][]string
+	err    string
+}
+
+// lookup returns the tokens of the objects of kind by name, calling list on
+// the first lookup of the kind.
+func (i *nameIndex) lookup(kind string, list func() (map[string][]string, string)) (map[string][]string, string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	entry, ok := i.entries[kind]
+	if !ok {
+		entry.tokens, entry.err = list()
+		if i.entries == nil {
+			i.entries = map[string]nameIndexEntry{}
+		}
+		i.entries[kind] = entry
+	}
+
+	return entry.tokens, entry.err
+}
+
+// forget drops the cached index of kind after objects of that kind were
+// created, renamed or deleted.
+func (i *nameIndex) forget(kind string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	delete(i.entries, kind)
+}
+
+// checkName fails when an object of kind other than the one with ownToken is
+// named name.
+func (i *nameIndex) checkName(resourceType string, kind string, name string, ownToken string, list func() (map[string][]string, string)) diag.Diagnostics {
+	tokens, err := i.lookup(kind, list)
+	if err != "" {
+		return diag.Diagnostics{nameNotCheckedDiagnostic(kind, err)}
+	}
+
+	for _, token := range tokens[name] {
+		if token != ownToken {
+			return diag.Diagnostics{duplicateNameDiagnostic(resourceType, kind, name, token)}
+		}
+	}
+
+	return nil
+}
+
+// checkSourceName fails when a source other than the one with ownToken is
+// named name.
+func (i *nameIndex) checkSourceName(ctx context.Context, client *api.ClientWithResponses, name string, ownToken string) diag.Diagnostics {
+	return i.checkName("logflare_source", "source", name, ownToken, func() (map[string][]string, string) {
+		httpResp, err := client.LogflareWebApiSourceControllerIndexWithResponse(ctx)
+		if err != nil {
+			return nil, err.Error()
+		}
+
+		if httpResp.JSON200 == nil {
+			return nil, fmt.Sprintf("got status %d: %s", httpResp.StatusCode(), httpResp.Body)
+		}
+
+		tokens := map[string][]string{}
+		for _, source := range *httpResp.JSON200 {
+			if source.Token != nil {
+				tokens[source.Name] = append(tokens[source.Name], *source.Token)
+			}
+		}
+		return tokens, ""
+	})
+}
+
+// checkEndpointName fails when an endpoint other than the one with ownToken
+// is named name.
+func (i *nameIndex) checkEndpointName(ctx context.Context, client *api.ClientWithResponses, name string, ownToken string) diag.Diagnostics {
+	return i.checkName("logflare_endpoint", "endpoint", name, ownToken, func() (map[string][]string, string) {
+		httpResp, err := client.LogflareWebApiEndpointControllerIndexWithResponse(ctx)
+		if err != nil {
+			return nil, err.Error()
+		}
+
+		if httpResp.JSON200 == nil {
+			return nil, fmt.Sprintf("got status %d: %s", httpResp.StatusCode(), httpResp.Body)
+		}
+
+		tokens := map[string][]string{}
+		for _, endpoint := range *httpResp.JSON200 {
+			if endpoint.Token != nil {
+				tokens[endpoint.Name] = append(tokens[endpoint.Name], *endpoint.Token)
+			}
+		}
+		return tokens, ""
+	})
+}
@@ -0,0 +1,113 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func TestCheckNames(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/sources":
+			_, _ = io.WriteString(w, `[{"name": "requests", "token": "source-token"}]`)
+		case "/api/endpoints":
+			_, _ = io.WriteString(w, `[{"name": "errors", "query": "select 1", "token": "endpoint-token"}]`)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	client, err := newLogflareClient(clientConfig{host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	names := &nameIndex{}
+	tests := []struct {
+		name  string
+		diags diag.Diagnostics
+		want  string
+	}{
+		{"duplicate source", names.checkSourceName(ctx, client, "requests", ""), "source-token"},
+		{"renamed to itself", names.checkSourceName(ctx, client, "requests", "source-token"), ""},
+		{"unused source name", names.checkSourceName(ctx, client, "other", ""), ""},
+		{"duplicate endpoint", names.checkEndpointName(ctx, client, "errors", ""), "endpoint-token"},
+		{"unused endpoint name", names.checkEndpointName(ctx, client, "requests", ""), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.want == "" {
+				if tt.diags.HasError() {
+					t.Errorf("unexpected diagnostics: %v", tt.diags)
+				}
+				return
+			}
+			if !tt.diags.HasError() || !strings.Contains(tt.diags[0].Detail(), tt.want) {
+				t.Errorf("expected an error naming %s, got %v", tt.want, tt.diags)
+			}
+		})
+	}
+}
+
+func TestCheckNamesUnavailable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	client, err := newLogflareClient(clientConfig{host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diags := (&nameIndex{}).checkSourceName(context.Background(), client, "requests", "")
+	if diags.HasError() || diags.WarningsCount() != 1 {
+		t.Errorf("expected a single warning, got %v", diags)
+	}
+}
+
+func TestCheckNamesListsOnce(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `[{"name": "requests", "token": "source-token"}]`)
+	}))
+	defer server.Close()
+
+	client, err := newLogflareClient(clientConfig{host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	names := &nameIndex{}
+	for _, name := range []string{"a", "b", "c", "requests"} {
+		names.checkSourceName(ctx, client, name, "")
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("expected the sources to be listed once, got %d calls", got)
+	}
+
+	names.forget("source")
+	if diags := names.checkSourceName(ctx, client, "requests", ""); !diags.HasError() {
+		t.Errorf("expected a duplicate name error, got %v", diags)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("expected the sources to be listed again after forget, got %d calls", got)
+	}
+}
@@ -5,25 +5,34 @@ package provider
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/supabase/terraform-provider-supabase-analytics/internal/pkg/api"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/net/http/httpguts"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ provider.Provider              = &logflareProvider{}
-	_ provider.ProviderWithFunctions = &logflareProvider{}
+	_ provider.Provider                  = &logflareProvider{}
+	_ provider.ProviderWithFunctions     = &logflareProvider{}
+	_ provider.ProviderWithListResources = &logflareProvider{}
 )
 
 // New is a helper function to simplify provider server and testing implementation.
@@ -37,8 +46,17 @@ func New(version string) func() provider.Provider {
 
 // logflareProviderModel maps provider schema data to a Go type.
 type logflareProviderModel struct {
-	Host        types.String `tfsdk:"host"`
-	AccessToken types.String `tfsdk:"access_token"`
+	Host               types.String  `tfsdk:"host"`
+	AccessToken        types.String  `tfsdk:"access_token"`
+	MaxRetries         types.Int32   `tfsdk:"max_retries"`
+	MaxBackoffSeconds  types.Int32   `tfsdk:"max_backoff_seconds"`
+	RequestsPerSecond  types.Float64 `tfsdk:"requests_per_second"`
+	MaxInFlight        types.Int32   `tfsdk:"max_in_flight_requests"`
+	Headers            types.Map     `tfsdk:"headers"`
+	CACert             types.String  `tfsdk:"ca_cert"`
+	ClientCert         types.String  `tfsdk:"client_cert"`
+	ClientKey          types.String  `tfsdk:"client_key"`
+	InsecureSkipVerify types.Bool    `tfsdk:"insecure_skip_verify"`
 }
 
 // logflareProvider is the provider implementation.
@@ -61,7 +79,7 @@ func (p *logflareProvider) Schema(_ context.Context, _ provider.SchemaRequest, r
 		Description: "Interact with Logflare.",
 		Attributes: map[string]schema.Attribute{
 			"host": schema.StringAttribute{
-				Description: "URI for Logflare API. Defaults to 'https://logflare.app'.",
+				Description: "URI for Logflare API. May also be provided via LOGFLARE_HOST or LOGFLARE_API_URL environment variable. Defaults to 'https://logflare.app'.",
 				Optional:    true,
 			},
 			"access_token": schema.StringAttribute{
@@ -69,6 +87,44 @@ func (p *logflareProvider) Schema(_ context.Context, _ provider.SchemaRequest, r
 				Optional:    true,
 				Sensitive:   true,
 			},
+			"max_retries": schema.Int32Attribute{
+				Description: "Maximum number of times a request that failed with a transient error is retried. Rate limited requests are always retried, other failures only for requests that are safe to repeat. Defaults to 4; set to 0 to disable retries.",
+				Optional:    true,
+			},
+			"max_backoff_seconds": schema.Int32Attribute{
+				Description: "Maximum number of seconds to wait between retries. Defaults to 30.",
+				Optional:    true,
+			},
+			"requests_per_second": schema.Float64Attribute{
+				Description: "Maximum number of requests per second sent to the Logflare API. The rate is lowered automatically when the server reports its remaining rate limit. Unlimited when not set.",
+				Optional:    true,
+			},
+			"max_in_flight_requests": schema.Int32Attribute{
+				Description: "Maximum number of requests to the Logflare API in flight at the same time, regardless of Terraform parallelism. Unlimited when not set.",
+				Optional:    true,
+			},
+			"headers": schema.MapAttribute{
+				Description: "Additional HTTP headers sent with every request to the Logflare API, such as routing headers required by a gateway in front of a self-hosted instance. The Authorization and User-Agent headers cannot be overridden.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"ca_cert": schema.StringAttribute{
+				Description: "PEM-encoded CA certificates, or the path to a file containing them, trusted in addition to the system roots when connecting to the Logflare API.",
+				Optional:    true,
+			},
+			"client_cert": schema.StringAttribute{
+				Description: "PEM-encoded client certificate, or the path to a file containing it, presented to the Logflare API for mutual TLS. Requires client_key.",
+				Optional:    true,
+			},
+			"client_key": schema.StringAttribute{
+				Description: "PEM-encoded private key of the client certificate, or the path to a file containing it. Requires client_cert.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				Description: "Skip verification of the Logflare API server certificate. Only intended for local development stacks.",
+				Optional:    true,
+			},
 		},
 	}
 }
@@ -87,20 +143,125 @@ func (p *logflareProvider) Configure(ctx context.Context, req provider.Configure
 	// If practitioner provided a configuration value for any of the
 	// attributes, it must be a known value.
 
+	if config.Host.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("host"),
+			"Unknown Logflare Host",
+			"The provider cannot create the Logflare API client as there is an unknown configuration value for the Logflare Host. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the LOGFLARE_HOST environment variable.",
+		)
+	}
+
+	if config.AccessToken.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("access_token"),
+			"Unknown Logflare Access Token",
+			"The provider cannot create the Logflare API client as there is an unknown configuration value for the Logflare Access Token. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the LOGFLARE_ACCESS_TOKEN environment variable.",
+		)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	if config.Host.IsNull() {
-		config.Host = types.StringValue("https://logflare.app")
+		config.Host = types.StringValue(hostFromEnv())
 	}
 
 	if config.AccessToken.IsNull() {
 		config.AccessToken = types.StringValue(os.Getenv("LOGFLARE_ACCESS_TOKEN"))
 	}
 
-	if config.AccessToken.IsUnknown() {
+	host, err := normalizeHost(config.Host.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("host"),
+			"Invalid Logflare Host",
+			"The provider cannot create the Logflare API client as the Logflare Host is invalid: "+err.Error(),
+		)
+	}
+	config.Host = types.StringValue(host)
+
+	if config.AccessToken.ValueString() == "" {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("access_token"),
-			"Unknown Logflare Access Token",
-			"The provider cannot create the Logflare API client as there is an unknown configuration value for the Logflare Access Token. "+
-				"Either target apply the source of the value first, set the value statically in the configuration, or use the LOGFLARE_ACCESS_TOKEN environment variable.",
+			"Missing Logflare Access Token",
+			"The provider cannot create the Logflare API client as there is a missing or empty value for the Logflare Access Token. "+
+				"Set the access_token value in the configuration or use the LOGFLARE_ACCESS_TOKEN environment variable.",
+		)
+	}
+
+	maxRetries := int32(defaultMaxRetries)
+	if !config.MaxRetries.IsNull() && !config.MaxRetries.IsUnknown() {
+		maxRetries = config.MaxRetries.ValueInt32()
+		if maxRetries < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_retries"),
+				"Invalid Maximum Retries",
+				fmt.Sprintf("The maximum number of retries must not be negative, got %d.", maxRetries),
+			)
+		}
+	}
+
+	maxBackoff := defaultMaxBackoff
+	if !config.MaxBackoffSeconds.IsNull() && !config.MaxBackoffSeconds.IsUnknown() {
+		maxBackoff = time.Duration(config.MaxBackoffSeconds.ValueInt32()) * time.Second
+		if maxBackoff < time.Second {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_backoff_seconds"),
+				"Invalid Maximum Backoff",
+				fmt.Sprintf("The maximum backoff must be at least 1 second, got %d.", config.MaxBackoffSeconds.ValueInt32()),
+			)
+		}
+	}
+
+	var requestsPerSecond float64
+	if !config.RequestsPerSecond.IsNull() && !config.RequestsPerSecond.IsUnknown() {
+		requestsPerSecond = config.RequestsPerSecond.ValueFloat64()
+		if requestsPerSecond <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("requests_per_second"),
+				"Invalid Requests Per Second",
+				fmt.Sprintf("The request rate must be greater than 0, got %g.", requestsPerSecond),
+			)
+		}
+	}
+
+	var maxInFlight int32
+	if !config.MaxInFlight.IsNull() && !config.MaxInFlight.IsUnknown() {
+		maxInFlight = config.MaxInFlight.ValueInt32()
+		if maxInFlight < 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_in_flight_requests"),
+				"Invalid Maximum In-Flight Requests",
+				fmt.Sprintf("The maximum number of in-flight requests must be at least 1, got %d.", maxInFlight),
+			)
+		}
+	}
+
+	headers := map[string]string{}
+	if !config.Headers.IsNull() && !config.Headers.IsUnknown() {
+		resp.Diagnostics.Append(config.Headers.ElementsAs(ctx, &headers, false)...)
+		for name, value := range headers {
+			if err := validateHeader(name, value); err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("headers").AtMapKey(name),
+					"Invalid Header",
+					fmt.Sprintf("The header %q cannot be sent to the Logflare API: %s.", name, err),
+				)
+			}
+		}
+	}
+
+	tlsConfig, diags := tlsConfigFromModel(config)
+	resp.Diagnostics.Append(diags...)
+
+	if config.InsecureSkipVerify.ValueBool() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("insecure_skip_verify"),
+			"Insecure TLS Configuration",
+			"The Logflare API server certificate is not verified. Do not use this setting outside of local development.",
 		)
 	}
 
@@ -115,15 +276,18 @@ func (p *logflareProvider) Configure(ctx context.Context, req provider.Configure
 	tflog.Debug(ctx, "Creating Logflare client")
 
 	// Create a new Logflare client using the configuration values
-	client, err := api.NewClientWithResponses(
-		config.Host.ValueString(),
-		api.WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
-			if !config.AccessToken.IsUnknown() {
-				req.Header.Set("Authorization", "Bearer "+config.AccessToken.ValueString())
-			}
-			return nil
-		}),
-	)
+	clientCfg := clientConfig{
+		host:              host,
+		accessToken:       config.AccessToken.ValueString(),
+		maxRetries:        int(maxRetries),
+		maxBackoff:        maxBackoff,
+		requestsPerSecond: requestsPerSecond,
+		maxInFlight:       int(maxInFlight),
+		userAgent:         userAgent(p.version, req.TerraformVersion),
+		headers:           headers,
+		tlsConfig:         tlsConfig,
+	}
+	client, err := newLogflareClient(clientCfg)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Create Logflare API Client",
@@ -134,12 +298,131 @@ func (p *logflareProvider) Configure(ctx context.Context, req provider.Configure
 		return
 	}
 
+	server := probeServer(ctx, clientCfg)
+	if server.version != nil && server.version.LessThan(apiVersion) {
+		resp.Diagnostics.AddWarning(
+			"Outdated Logflare Server",
+			fmt.Sprintf("The Logflare server runs version %s, but this provider is built against Logflare %s. "+
+				"Attributes that the server does not know about may be ignored.", server.version, apiVersion),
+		)
+	}
+
 	resp.DataSourceData = client
-	resp.ResourceData = client
+	resp.ResourceData = &logflareProviderData{client: client, names: &nameIndex{}, server: server}
+	resp.ListResourceData = client
 
 	tflog.Info(ctx, "Configured Logflare client", map[string]any{"success": true})
 }
 
+// hostFromEnv returns the Logflare host from the environment, falling back to
+// the hosted Logflare service.
+func hostFromEnv() string {
+	for _, key := range []string{"LOGFLARE_HOST", "LOGFLARE_API_URL"} {
+		if value := os.Getenv(key); value != "" {
+			return value
+		}
+	}
+	return "https://logflare.app"
+}
+
+// normalizeHost validates that host is an absolute http(s) URL and strips any
+// trailing slashes, which would otherwise produce double slashes in API paths.
+func normalizeHost(host string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(host))
+	if err != nil {
+		return "", err
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("expected an http or https URL, got %q", host)
+	}
+
+	if u.Host == "" {
+		return "", fmt.Errorf("missing host name in %q", host)
+	}
+
+	if u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("unexpected query or fragment in %q", host)
+	}
+
+	return strings.TrimRight(u.String(), "/"), nil
+}
+
+// clientConfig holds the settings used to build a Logflare API client.
+type clientConfig struct {
+	host        string
+	accessToken string
+	maxRetries  int
+	maxBackoff  time.Duration
+
+	// requestsPerSecond and maxInFlight are unlimited when zero.
+	requestsPerSecond float64
+	maxInFlight       int
+
+	userAgent string
+	headers   map[string]string
+
+	// tlsConfig replaces the default TLS settings when set.
+	tlsConfig *tls.Config
+}
+
+// newLogflareClient creates an API client for the Logflare instance at
+// config.host that authenticates with the configured access token, retries
+// transient failures and throttles requests. Every retry attempt passes
+// through the rate limiter and is traced individually.
+func newLogflareClient(config clientConfig) (*api.ClientWithResponses, error) {
+	base := &http.Transport{Proxy: http.ProxyFromEnvironment}
+	if defaultTransport, ok := http.DefaultTransport.(*http.Transport); ok {
+		base = defaultTransport.Clone()
+	}
+	if config.tlsConfig != nil {
+		base.TLSClientConfig = config.tlsConfig
+	}
+
+	transport := newLoggingTransport(base)
+	transport = newRateLimitTransport(transport, config.requestsPerSecond, config.maxInFlight)
+	transport = newRetryTransport(transport, config.maxRetries, config.maxBackoff)
+
+	return api.NewClientWithResponses(
+		config.host,
+		api.WithHTTPClient(&http.Client{Transport: transport}),
+		api.WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+			for name, value := range config.headers {
+				req.Header.Set(name, value)
+			}
+			req.Header.Set("User-Agent", config.userAgent)
+			req.Header.Set("Authorization", "Bearer "+config.accessToken)
+			return nil
+		}),
+	)
+}
+
+// userAgent identifies the provider, and the Terraform version it runs under
+// when known, to the Logflare API.
+func userAgent(providerVersion string, terraformVersion string) string {
+	ua := "terraform-provider-logflare/" + providerVersion
+	if terraformVersion != "" {
+		ua = fmt.Sprintf("Terraform/%s (+https://www.terraform.io) %s", terraformVersion, ua)
+	}
+	return ua
+}
+
+// validateHeader checks that a custom header is well formed and does not
+// replace one of the headers set by the provider itself.
+func validateHeader(name string, value string) error {
+	if !httpguts.ValidHeaderFieldName(name) {
+		return errors.New("invalid header name")
+	}
+	if !httpguts.ValidHeaderFieldValue(value) {
+		return errors.New("invalid header value")
+	}
+	switch http.CanonicalHeaderKey(name) {
+	case "Authorization", "User-Agent":
+		return errors.New("the header is set by the provider")
+	}
+	return nil
+}
+
 // DataSources defines the data sources implemented in the provider.
 func (p *logflareProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
@@ -150,8 +433,21 @@ func (p *logflareProvider) DataSources(_ context.Context) []func() datasource.Da
 // Resources defines the resources implemented in the provider.
 func (p *logflareProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
+		NewBackendResource,
 		NewEndpointResource,
+		NewRuleResource,
 		NewSourceResource,
+		NewSourceSchemaSeedResource,
+	}
+}
+
+// ListResources defines the list resources implemented in the provider.
+func (p *logflareProvider) ListResources(_ context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		NewBackendListResource,
+		NewEndpointListResource,
+		NewRuleListResource,
+		NewSourceListResource,
 	}
 }
 
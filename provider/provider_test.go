@@ -4,6 +4,12 @@
 package provider
 
 import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 )
@@ -23,3 +29,75 @@ provider "logflare" {
 var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
 	"logflare": providerserver.NewProtocol6WithError(New("test")()),
 }
+
+func TestNormalizeHost(t *testing.T) {
+	valid := map[string]string{
+		"https://logflare.app":          "https://logflare.app",
+		"http://localhost:4000/":        "http://localhost:4000",
+		"https://logs.example.com/lf//": "https://logs.example.com/lf",
+	}
+	for host, want := range valid {
+		got, err := normalizeHost(host)
+		if err != nil {
+			t.Errorf("normalizeHost(%q) returned error: %s", host, err)
+		} else if got != want {
+			t.Errorf("normalizeHost(%q) = %q, want %q", host, got, want)
+		}
+	}
+
+	for _, host := range []string{"", "localhost:4000", "ftp://logflare.app", "https://", "https://logflare.app/?a=b"} {
+		if _, err := normalizeHost(host); err == nil {
+			t.Errorf("normalizeHost(%q) should return an error", host)
+		}
+	}
+}
+
+func TestNewLogflareClientHeaders(t *testing.T) {
+	var got http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		_, _ = io.WriteString(w, `[]`)
+	}))
+	defer server.Close()
+
+	client, err := newLogflareClient(clientConfig{
+		host:        server.URL,
+		accessToken: "my-cool-api-key-123",
+		userAgent:   userAgent("1.2.3", "1.9.0"),
+		headers:     map[string]string{"X-Tenant": "acme"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.LogflareWebApiSourceControllerIndexWithResponse(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		"Authorization": "Bearer my-cool-api-key-123",
+		"User-Agent":    "Terraform/1.9.0 (+https://www.terraform.io) terraform-provider-logflare/1.2.3",
+		"X-Tenant":      "acme",
+	}
+	for name, value := range want {
+		if got.Get(name) != value {
+			t.Errorf("header %s = %q, want %q", name, got.Get(name), value)
+		}
+	}
+}
+
+func TestValidateHeader(t *testing.T) {
+	if err := validateHeader("X-Tenant", "acme"); err != nil {
+		t.Errorf("validateHeader returned error: %s", err)
+	}
+
+	for name, value := range map[string]string{
+		"authorization": "Bearer other",
+		"User-Agent":    "curl",
+		"X Tenant":      "acme",
+		"X-Tenant":      "line\nbreak",
+	} {
+		if err := validateHeader(name, value); err == nil {
+			t.Errorf("validateHeader(%q, %q) should return an error", name, value)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/time/rate"
+)
+
+// rateLimitTransport throttles requests to a configured rate and caps the
+// number of requests in flight. When the server reports its remaining
+// request budget, the rate is lowered to spread that budget over the
+// remaining window, and requests are paused once the budget is exhausted.
+type rateLimitTransport struct {
+	next http.RoundTripper
+
+	// limit is the configured rate, rate.Inf when unlimited.
+	limit   rate.Limit
+	limiter *rate.Limiter
+
+	// slots holds one element per request in flight, nil when unlimited.
+	slots chan struct{}
+
+	mu          sync.Mutex
+	pausedUntil time.Time
+}
+
+func newRateLimitTransport(base http.RoundTripper, requestsPerSecond float64, maxInFlight int) http.RoundTripper {
+	limit := rate.Inf
+	if requestsPerSecond > 0 {
+		limit = rate.Limit(requestsPerSecond)
+	}
+
+	t := &rateLimitTransport{
+		next:    base,
+		limit:   limit,
+		limiter: rate.NewLimiter(limit, max(1, int(math.Ceil(requestsPerSecond)))),
+	}
+	if maxInFlight > 0 {
+		t.slots = make(chan struct{}, maxInFlight)
+	}
+
+	return t
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	if err := t.waitForPause(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := t.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	if t.slots != nil {
+		select {
+		case t.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		t.release()
+		return nil, err
+	}
+
+	t.adjust(ctx, resp)
+
+	// The request stays in flight until its body has been consumed.
+	resp.Body = &releaseOnClose{ReadCloser: resp.Body, release: t.release}
+
+	return resp, nil
+}
+
+func (t *rateLimitTransport) release() {
+	if t.slots != nil {
+		<-t.slots
+	}
+}
+
+func (t *rateLimitTransport) waitForPause(ctx context.Context) error {
+	t.mu.Lock()
+	wait := time.Until(t.pausedUntil)
+	t.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+
+	tflog.Debug(ctx, "Waiting for the Logflare API rate limit to reset", map[string]any{"wait": wait.String()})
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// adjust updates the limiter from the rate limit headers of resp, if any.
+func (t *rateLimitTransport) adjust(ctx context.Context, resp *http.Response) {
+	remaining, reset, ok := parseRateLimitHeaders(resp.Header, time.Now())
+	if !ok {
+		return
+	}
+
+	if remaining == 0 {
+		t.mu.Lock()
+		t.pausedUntil = time.Now().Add(reset)
+		t.mu.Unlock()
+		return
+	}
+
+	limit := min(t.limit, rate.Limit(float64(remaining)/reset.Seconds()))
+	if limit != t.limiter.Limit() {
+		tflog.Debug(ctx, "Adjusting Logflare API request rate", map[string]any{
+			"requests_per_second": float64(limit),
+			"remaining":           remaining,
+			"reset":               reset.String(),
+		})
+		t.limiter.SetLimit(limit)
+	}
+}
+
+// parseRateLimitHeaders reads the remaining request budget and the time until
+// it resets from the RateLimit-* or X-RateLimit-* response headers. Reset
+// values are accepted both as seconds and as a Unix timestamp.
+func parseRateLimitHeaders(header http.Header, now time.Time) (int, time.Duration, bool) {
+	for _, prefix := range []string{"RateLimit-", "X-RateLimit-"} {
+		remainingValue := header.Get(prefix + "Remaining")
+		resetValue := header.Get(prefix + "Reset")
+		if remainingValue == "" || resetValue == "" {
+			continue
+		}
+
+		remaining, err := strconv.Atoi(remainingValue)
+		if err != nil || remaining < 0 {
+			return 0, 0, false
+		}
+
+		seconds, err := strconv.ParseInt(resetValue, 10, 64)
+		if err != nil || seconds < 0 {
+			return 0, 0, false
+		}
+
+		reset := time.Duration(seconds) * time.Second
+		if seconds > now.Unix() {
+			reset = time.Unix(seconds, 0).Sub(now)
+		}
+		if reset <= 0 {
+			return 0, 0, false
+		}
+
+		return remaining, reset, true
+	}
+
+	return 0, 0, false
+}
+
+// releaseOnClose calls release the first time the body is closed.
+type releaseOnClose struct {
+	io.ReadCloser
+	release func()
+	once    sync.Once
+}
+
+func (b *releaseOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
@@ -0,0 +1,118 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+func TestParseRateLimitHeaders(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	testCases := map[string]struct {
+		header        http.Header
+		wantRemaining int
+		wantReset     time.Duration
+		wantOk        bool
+	}{
+		"none": {
+			header: http.Header{},
+		},
+		"seconds": {
+			header:        http.Header{"Ratelimit-Remaining": {"10"}, "Ratelimit-Reset": {"5"}},
+			wantRemaining: 10,
+			wantReset:     5 * time.Second,
+			wantOk:        true,
+		},
+		"unix timestamp": {
+			header:        http.Header{"X-Ratelimit-Remaining": {"0"}, "X-Ratelimit-Reset": {"1700000030"}},
+			wantRemaining: 0,
+			wantReset:     30 * time.Second,
+			wantOk:        true,
+		},
+		"missing reset": {
+			header: http.Header{"X-Ratelimit-Remaining": {"3"}},
+		},
+		"invalid": {
+			header: http.Header{"Ratelimit-Remaining": {"many"}, "Ratelimit-Reset": {"5"}},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			remaining, reset, ok := parseRateLimitHeaders(testCase.header, now)
+			if remaining != testCase.wantRemaining || reset != testCase.wantReset || ok != testCase.wantOk {
+				t.Errorf("got (%d, %s, %t), want (%d, %s, %t)", remaining, reset, ok, testCase.wantRemaining, testCase.wantReset, testCase.wantOk)
+			}
+		})
+	}
+}
+
+func TestRateLimitTransportMaxInFlight(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			old := peak.Load()
+			if current <= old || peak.CompareAndSwap(old, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newRateLimitTransport(http.DefaultTransport, 0, 2)}
+
+	var wg sync.WaitGroup
+	for range 6 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get(server.URL)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if got := peak.Load(); got > 2 {
+		t.Errorf("got %d requests in flight, want at most 2", got)
+	}
+}
+
+func TestRateLimitTransportAdjust(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("RateLimit-Remaining", "20")
+		w.Header().Set("RateLimit-Reset", "10")
+	}))
+	defer server.Close()
+
+	transport, ok := newRateLimitTransport(http.DefaultTransport, 100, 0).(*rateLimitTransport)
+	if !ok {
+		t.Fatal("unexpected transport type")
+	}
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if got := transport.limiter.Limit(); got != rate.Limit(2) {
+		t.Errorf("got limit %v, want 2", got)
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	defaultMaxRetries = 4
+	defaultMaxBackoff = 30 * time.Second
+)
+
+// retryMethodKey is the context key under which retryTransport stores the
+// method of the request being retried, as retry policies only receive the
+// request context.
+type retryMethodKey struct{}
+
+// retryTransport retries transient Logflare API failures with exponential
+// backoff.
+type retryTransport struct {
+	next http.RoundTripper
+}
+
+func newRetryTransport(base http.RoundTripper, maxRetries int, maxBackoff time.Duration) http.RoundTripper {
+	client := &retryablehttp.Client{
+		HTTPClient:     &http.Client{Transport: base},
+		RetryWaitMin:   min(time.Second, maxBackoff),
+		RetryWaitMax:   maxBackoff,
+		RetryMax:       maxRetries,
+		CheckRetry:     retryPolicy,
+		Backoff:        retryablehttp.DefaultBackoff,
+		ErrorHandler:   retryablehttp.PassthroughErrorHandler,
+		RequestLogHook: logRetry,
+	}
+
+	return &retryTransport{next: &retryablehttp.RoundTripper{Client: client}}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := context.WithValue(req.Context(), retryMethodKey{}, req.Method)
+	return t.next.RoundTrip(req.WithContext(ctx))
+}
+
+// retryPolicy retries 429 responses, which the server rejected without
+// processing, for every request. Server errors and connection failures are
+// only retried for idempotent requests so that creates are never duplicated.
+func retryPolicy(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+
+	if err == nil && resp.StatusCode == http.StatusTooManyRequests {
+		tflog.Debug(ctx, "Logflare API rate limited the request", map[string]any{"status": resp.StatusCode})
+		return true, nil
+	}
+
+	method, _ := ctx.Value(retryMethodKey{}).(string)
+	if !isIdempotentMethod(method) {
+		return false, nil
+	}
+
+	retry, retryErr := retryablehttp.DefaultRetryPolicy(ctx, resp, err)
+	if !retry {
+		return false, retryErr
+	}
+
+	fields := map[string]any{}
+	if err != nil {
+		fields["error"] = err.Error()
+	} else {
+		fields["status"] = resp.StatusCode
+	}
+	tflog.Debug(ctx, "Logflare API request failed with a transient error", fields)
+
+	return true, nil
+}
+
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+func logRetry(_ retryablehttp.Logger, req *http.Request, attempt int) {
+	if attempt == 0 {
+		return
+	}
+
+	tflog.Warn(req.Context(), "Retrying Logflare API request", map[string]any{
+		"method":  req.Method,
+		"path":    req.URL.Path,
+		"attempt": attempt,
+	})
+}
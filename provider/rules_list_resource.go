@@ -0,0 +1,132 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/supabase/terraform-provider-supabase-analytics/internal/pkg/api"
+)
+
+var (
+	_ list.ListResource              = &RuleListResource{}
+	_ list.ListResourceWithConfigure = &RuleListResource{}
+)
+
+func NewRuleListResource() list.ListResource {
+	return &RuleListResource{}
+}
+
+// RuleListResource lists existing rules for `terraform query`.
+type RuleListResource struct {
+	client *api.ClientWithResponses
+}
+
+type RuleListResourceModel struct {
+	BackendId types.Int64 `tfsdk:"backend_id"`
+	SourceId  types.Int64 `tfsdk:"source_id"`
+}
+
+func (r *RuleListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rule"
+}
+
+func (r *RuleListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		Description: "Lists rules.",
+		Attributes: map[string]listschema.Attribute{
+			"backend_id": listschema.Int64Attribute{
+				Description: "Only list rules that route events to the backend with this identifier.",
+				Optional:    true,
+			},
+			"source_id": listschema.Int64Attribute{
+				Description: "Only list rules of the source with this identifier.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (r *RuleListResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.ClientWithResponses)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected *api.ClientWithResponses, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *RuleListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config RuleListResourceModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	httpResp, err := r.client.LogflareWebApiRuleControllerIndexWithResponse(ctx)
+	if err != nil {
+		msg := fmt.Sprintf("Unable to list rules, got error: %s", err)
+		stream.Results = list.ListResultsStreamDiagnostics(diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", msg)})
+		return
+	}
+
+	if httpResp.JSON200 == nil {
+		msg := fmt.Sprintf("Unable to list rules, got status %d: %s", httpResp.StatusCode(), httpResp.Body)
+		stream.Results = list.ListResultsStreamDiagnostics(diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", msg)})
+		return
+	}
+
+	rules := *httpResp.JSON200
+	stream.Results = func(push func(list.ListResult) bool) {
+		var count int64
+		for _, rule := range rules {
+			if req.Limit > 0 && count >= req.Limit {
+				return
+			}
+			if !matchesIdFilter(config.SourceId, rule.SourceId) || !matchesIdFilter(config.BackendId, rule.BackendId) {
+				continue
+			}
+
+			result := req.NewListResult(ctx)
+			result.DisplayName = types.StringPointerValue(rule.LqlString).ValueString()
+			result.Diagnostics.Append(result.Identity.Set(ctx, tokenIdentityModel{Token: types.StringPointerValue(rule.Token)})...)
+
+			if req.IncludeResource {
+				data := RuleResourceModel{Timeouts: nullTimeouts()}
+				ruleApiSchemaToModel(&rule, &data)
+				result.Diagnostics.Append(result.Resource.Set(ctx, &data)...)
+			}
+
+			count++
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
+
+// matchesIdFilter reports whether id passes filter, which matches every id
+// when unset.
+func matchesIdFilter(filter types.Int64, id *int) bool {
+	if filter.IsNull() || filter.IsUnknown() {
+		return true
+	}
+	return id != nil && int64(*id) == filter.ValueInt64()
+}
@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/querycheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccRulesListResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccRulesListResourceConfig,
+			},
+			{
+				Query:  true,
+				Config: providerConfig + testAccRulesListResourceQuery,
+				QueryResultChecks: []querycheck.QueryResultCheck{
+					querycheck.ExpectLengthAtLeast("logflare_rule.all", 1),
+				},
+			},
+		},
+	})
+}
+
+const testAccRulesListResourceConfig = `
+resource "logflare_source" "rule_list_test" {
+	name = "my-listed-rule-source"
+	deletion_protection = false
+}
+
+resource "logflare_backend" "rule_list_test" {
+	name   = "my-listed-rule-backend"
+	type   = "webhook"
+	config = jsonencode({ url = "https://example.com/rules" })
+}
+
+resource "logflare_rule" "rule_list_test" {
+	source_id  = logflare_source.rule_list_test.id
+	backend_id = logflare_backend.rule_list_test.id
+	lql_string = "m.status_code:>499"
+}
+`
+
+const testAccRulesListResourceQuery = `
+list "logflare_rule" "all" {
+	provider = logflare
+}
+`
+
+func TestMatchesIdFilter(t *testing.T) {
+	id := 7
+	tests := []struct {
+		name   string
+		filter types.Int64
+		id     *int
+		want   bool
+	}{
+		{name: "unset filter", filter: types.Int64Null(), id: &id, want: true},
+		{name: "matching id", filter: types.Int64Value(7), id: &id, want: true},
+		{name: "other id", filter: types.Int64Value(8), id: &id, want: false},
+		{name: "missing id", filter: types.Int64Value(7), id: nil, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchesIdFilter(tt.filter, tt.id); got != tt.want {
+				t.Errorf("matchesIdFilter(%s, %v) = %t, want %t", tt.filter, tt.id, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,338 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/supabase/terraform-provider-supabase-analytics/internal/pkg/api"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                 = &RuleResource{}
+	_ resource.ResourceWithIdentity     = &RuleResource{}
+	_ resource.ResourceWithImportState  = &RuleResource{}
+	_ resource.ResourceWithUpgradeState = &RuleResource{}
+)
+
+func NewRuleResource() resource.Resource {
+	return &RuleResource{}
+}
+
+// RuleResource defines the resource implementation.
+type RuleResource struct {
+	client *api.ClientWithResponses
+}
+
+// RuleResourceModel describes the resource data model.
+type RuleResourceModel struct {
+	BackendId types.Int64    `tfsdk:"backend_id"`
+	Id        types.Int64    `tfsdk:"id"`
+	LqlString types.String   `tfsdk:"lql_string"`
+	SourceId  types.Int64    `tfsdk:"source_id"`
+	Token     types.String   `tfsdk:"token"`
+	Timeouts  timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *RuleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rule"
+}
+
+func (r *RuleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Rule resource. Rules route the events of a source that match an LQL filter to a backend.",
+		Version:             int64(len(ruleStateUpgradeSteps)),
+
+		Attributes: map[string]schema.Attribute{
+			"backend_id": schema.Int64Attribute{
+				MarkdownDescription: "Identifier of the backend that receives the matching events",
+				Required:            true,
+			},
+			"id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Rule identifier",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"lql_string": schema.StringAttribute{
+				MarkdownDescription: "LQL filter selecting the events to route, for example `m.status_code:>499`",
+				Required:            true,
+			},
+			"source_id": schema.Int64Attribute{
+				MarkdownDescription: "Identifier of the source whose events are routed. Changing it replaces the rule.",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "Token of the rule",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *RuleResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return stateUpgraders(ruleStateUpgradeSteps...)
+}
+
+// ruleStateUpgradeSteps upgrade the state of logflare_rule one schema version
+// at a time. The schema version is the number of steps; append a step
+// whenever an attribute changes type.
+var ruleStateUpgradeSteps = []stateUpgradeStep{}
+
+func (r *RuleResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = tokenIdentitySchema()
+}
+
+func (r *RuleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*logflareProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *logflareProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *RuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RuleResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(withTimeoutDiagnostics(ctx, createRule(ctx, &data, r.client), "create", "logflare_rule", createTimeout)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created a resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, tokenIdentityModel{Token: data.Token})...)
+}
+
+func createRule(ctx context.Context, data *RuleResourceModel, client *api.ClientWithResponses) diag.Diagnostics {
+	httpResp, err := client.LogflareWebApiRuleControllerCreateWithResponse(ctx, ruleResourceToApiSchema(data))
+	if err != nil {
+		msg := fmt.Sprintf("Unable to create rule, got error: %s", err)
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", msg)}
+	}
+
+	if httpResp.JSON201 == nil {
+		msg := fmt.Sprintf("Unable to create rule, got status %d: %s", httpResp.StatusCode(), httpResp.Body)
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", msg)}
+	}
+
+	ruleApiSchemaToModel(httpResp.JSON201, data)
+	return nil
+}
+
+func (r *RuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RuleResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	if data.Token.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(withTimeoutDiagnostics(ctx, readRule(ctx, &data, r.client), "read", "logflare_rule", readTimeout)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, tokenIdentityModel{Token: data.Token})...)
+}
+
+func readRule(ctx context.Context, data *RuleResourceModel, client *api.ClientWithResponses) diag.Diagnostics {
+	httpResp, err := client.LogflareWebApiRuleControllerShowWithResponse(ctx, data.Token.ValueString())
+	if err != nil {
+		msg := fmt.Sprintf("Unable to read rule, got error: %s", err)
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", msg)}
+	}
+
+	if httpResp.JSON200 == nil {
+		msg := fmt.Sprintf("Unable to read rule, got status %d: %s", httpResp.StatusCode(), httpResp.Body)
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", msg)}
+	}
+
+	ruleApiSchemaToModel(httpResp.JSON200, data)
+	return nil
+}
+
+func (r *RuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data RuleResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(withTimeoutDiagnostics(ctx, updateRule(ctx, &data, r.client), "update", "logflare_rule", updateTimeout)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, tokenIdentityModel{Token: data.Token})...)
+}
+
+func updateRule(ctx context.Context, data *RuleResourceModel, client *api.ClientWithResponses) diag.Diagnostics {
+	httpResp, err := client.LogflareWebApiRuleControllerUpdateWithResponse(ctx, data.Token.ValueString(), ruleResourceToApiSchema(data))
+	if err != nil {
+		msg := fmt.Sprintf("Unable to update rule, got error: %s", err)
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", msg)}
+	}
+
+	if httpResp.JSON200 == nil {
+		msg := fmt.Sprintf("Unable to update rule, got status %d: %s", httpResp.StatusCode(), httpResp.Body)
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", msg)}
+	}
+
+	ruleApiSchemaToModel(httpResp.JSON200, data)
+	return nil
+}
+
+func (r *RuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RuleResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	if data.Token.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(withTimeoutDiagnostics(ctx, deleteRule(ctx, &data, r.client), "delete", "logflare_rule", deleteTimeout)...)
+}
+
+func deleteRule(ctx context.Context, data *RuleResourceModel, client *api.ClientWithResponses) diag.Diagnostics {
+	httpResp, err := client.LogflareWebApiRuleControllerDeleteWithResponse(ctx, data.Token.ValueString())
+	if err != nil {
+		msg := fmt.Sprintf("Unable to delete rule, got error: %s", err)
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", msg)}
+	}
+
+	if httpResp.HTTPResponse.StatusCode != 204 {
+		msg := fmt.Sprintf("Unable to delete rule, got status %d: %s", httpResp.StatusCode(), httpResp.Body)
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", msg)}
+	}
+
+	return nil
+}
+
+func (r *RuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("token"), path.Root("token"), req, resp)
+}
+
+func ruleApiSchemaToModel(result *api.RuleApiSchema, data *RuleResourceModel) {
+	data.BackendId = types.Int64PointerValue(intPtrToInt64Ptr(result.BackendId))
+	data.Id = types.Int64PointerValue(intPtrToInt64Ptr(result.Id))
+	data.LqlString = types.StringPointerValue(result.LqlString)
+	data.SourceId = types.Int64PointerValue(intPtrToInt64Ptr(result.SourceId))
+	data.Token = types.StringPointerValue(result.Token)
+}
+
+func ruleResourceToApiSchema(data *RuleResourceModel) api.RuleApiSchema {
+	return api.RuleApiSchema{
+		BackendId: int64PtrToIntPtr(data.BackendId.ValueInt64Pointer()),
+		LqlString: data.LqlString.ValueStringPointer(),
+		SourceId:  int64PtrToIntPtr(data.SourceId.ValueInt64Pointer()),
+	}
+}
+
+func int64PtrToIntPtr(i *int64) *int {
+	if i == nil {
+		return nil
+	}
+	val := int(*i)
+	return &val
+}
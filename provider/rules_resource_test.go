@@ -0,0 +1,80 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/supabase/terraform-provider-supabase-analytics/internal/pkg/api"
+)
+
+func TestAccRulesResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccRulesResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("logflare_rule.rule_test", "lql_string", "m.status_code:>499"),
+					resource.TestCheckResourceAttrPair("logflare_rule.rule_test", "source_id", "logflare_source.rule_test", "id"),
+					resource.TestCheckResourceAttrPair("logflare_rule.rule_test", "backend_id", "logflare_backend.rule_test", "id"),
+				),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentityValueMatchesState("logflare_rule.rule_test", tfjsonpath.New("token")),
+				},
+			},
+			// Import by identity.
+			{
+				ResourceName:    "logflare_rule.rule_test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+		},
+	})
+}
+
+const testAccRulesResourceConfig = `
+resource "logflare_source" "rule_test" {
+	name = "my-routed-source"
+	deletion_protection = false
+}
+
+resource "logflare_backend" "rule_test" {
+	name   = "my-routed-backend"
+	type   = "webhook"
+	config = jsonencode({ url = "https://example.com/errors" })
+}
+
+resource "logflare_rule" "rule_test" {
+	source_id  = logflare_source.rule_test.id
+	backend_id = logflare_backend.rule_test.id
+	lql_string = "m.status_code:>499"
+}
+`
+
+func TestRuleApiSchemaRoundTrip(t *testing.T) {
+	id, sourceID, backendID := 5, 11, 3
+	lql, token := "m.status_code:>499", "rule-token"
+	result := api.RuleApiSchema{
+		BackendId: &backendID,
+		Id:        &id,
+		LqlString: &lql,
+		SourceId:  &sourceID,
+		Token:     &token,
+	}
+
+	var data RuleResourceModel
+	ruleApiSchemaToModel(&result, &data)
+	if data.Id.ValueInt64() != 5 || data.SourceId.ValueInt64() != 11 || data.BackendId.ValueInt64() != 3 || data.Token.ValueString() != token {
+		t.Errorf("unexpected model %+v", data)
+	}
+
+	body := ruleResourceToApiSchema(&data)
+	if *body.SourceId != sourceID || *body.BackendId != backendID || *body.LqlString != lql || body.Token != nil {
+		t.Errorf("unexpected body %+v", body)
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/supabase/terraform-provider-supabase-analytics/internal/pkg/api"
+)
+
+// apiVersion is the Logflare version api/openapi.yaml was generated from.
+var apiVersion = version.Must(version.NewVersion("1.23.3"))
+
+// logflareProviderData is passed to resources by Configure. Data sources and
+// list resources only need the client and receive it directly.
+type logflareProviderData struct {
+	client *api.ClientWithResponses
+	names  *nameIndex
+	server *serverInfo
+}
+
+// serverInfo describes the Logflare server the provider is connected to.
+type serverInfo struct {
+	// version is nil when the server did not report its version.
+	version *version.Version
+}
+
+// serverRequirement describes a resource type, or a single attribute of it,
+// that is not available on every Logflare server.
+type serverRequirement struct {
+	// attribute is the top-level attribute name, or empty when the whole
+	// resource type is affected.
+	attribute string
+	// minVersion is the first Logflare version that supports the feature.
+	minVersion *version.Version
+}
+
+// logflare1_23 is the first Logflare release whose API accepts the endpoint
+// language, labels, PII redaction and backend settings, and the advanced
+// source settings. Servers up to 1.22.x silently drop them.
+var logflare1_23 = version.Must(version.NewVersion("1.23.0"))
+
+// serverRequirements lists, by resource type name, the features that only
+// some Logflare servers support.
+var serverRequirements = map[string][]serverRequirement{
+	"logflare_endpoint": {
+		{attribute: "backend_id", minVersion: logflare1_23},
+		{attribute: "labels", minVersion: logflare1_23},
+		{attribute: "language", minVersion: logflare1_23},
+		{attribute: "redact_pii", minVersion: logflare1_23},
+	},
+	"logflare_source": {
+		{attribute: "bigquery_clustering_fields", minVersion: logflare1_23},
+		{attribute: "drop_lql_filters", minVersion: logflare1_23},
+		{attribute: "lock_schema", minVersion: logflare1_23},
+		{attribute: "retention_days", minVersion: logflare1_23},
+		{attribute: "suggested_keys", minVersion: logflare1_23},
+		{attribute: "transform_copy_fields", minVersion: logflare1_23},
+		{attribute: "validate_schema", minVersion: logflare1_23},
+	},
+}
+
+// probeServer asks the Logflare server for its version. The probe is sent
+// once, without retries, so that an unreachable server does not hold up every
+// provider configuration. Servers that cannot be probed are reported with
+// unknown capabilities, so that older or restricted deployments keep working
+// without plan-time checks.
+func probeServer(ctx context.Context, config clientConfig) *serverInfo {
+	info := &serverInfo{}
+
+	config.maxRetries = 0
+	client, err := newLogflareClient(config)
+	if err != nil {
+		tflog.Warn(ctx, "Unable to probe the Logflare server", map[string]any{"error": err.Error()})
+		return info
+	}
+
+	httpResp, err := client.LogflareWebHealthCheckControllerCheckWithResponse(ctx)
+	if err != nil {
+		tflog.Warn(ctx, "Unable to probe the Logflare server", map[string]any{"error": err.Error()})
+		return info
+	}
+	if httpResp.StatusCode() != 200 {
+		tflog.Warn(ctx, "Unable to probe the Logflare server", map[string]any{"status": httpResp.StatusCode()})
+		return info
+	}
+
+	if httpResp.JSON200 != nil && httpResp.JSON200.Version != nil {
+		v, err := version.NewVersion(*httpResp.JSON200.Version)
+		if err != nil {
+			tflog.Warn(ctx, "Logflare server reported an invalid version", map[string]any{"version": *httpResp.JSON200.Version})
+		} else {
+			info.version = v
+		}
+	}
+
+	tflog.Info(ctx, "Probed Logflare server", map[string]any{"version": info.versionString()})
+
+	return info
+}
+
+func (s *serverInfo) versionString() string {
+	if s == nil || s.version == nil {
+		return "unknown"
+	}
+	return s.version.String()
+}
+
+// unsupported returns why req cannot be used with the server, or an empty
+// string when it can or the server capabilities are unknown.
+func (s *serverInfo) unsupported(req serverRequirement) string {
+	if s == nil {
+		return ""
+	}
+
+	if req.minVersion != nil && s.version != nil && s.version.LessThan(req.minVersion) {
+		return fmt.Sprintf("requires Logflare %s or later, but the server runs %s", req.minVersion, s.version)
+	}
+
+	return ""
+}
+
+// validateConfig reports the parts of config for the given resource type that
+// the server does not support.
+func (s *serverInfo) validateConfig(ctx context.Context, resourceType string, config tfsdk.Config) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if config.Raw.IsNull() {
+		return diags
+	}
+
+	for _, req := range serverRequirements[resourceType] {
+		reason := s.unsupported(req)
+		if reason == "" {
+			continue
+		}
+
+		if req.attribute == "" {
+			diags.AddError(
+				"Unsupported Resource Type",
+				fmt.Sprintf("The %s resource %s.", resourceType, reason),
+			)
+			continue
+		}
+
+		value, _, err := tftypes.WalkAttributePath(config.Raw, tftypes.NewAttributePath().WithAttributeName(req.attribute))
+		if err != nil {
+			tflog.Debug(ctx, "Unable to read attribute for server requirement", map[string]any{"attribute": req.attribute, "error": err.Error()})
+			continue
+		}
+		if v, ok := value.(tftypes.Value); !ok || v.IsNull() {
+			continue
+		}
+
+		diags.AddAttributeError(
+			path.Root(req.attribute),
+			"Unsupported Attribute",
+			fmt.Sprintf("The %s attribute of %s %s. Remove it from the configuration or upgrade the server.", req.attribute, resourceType, reason),
+		)
+	}
+
+	return diags
+}
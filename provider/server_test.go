@@ -0,0 +1,182 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestProbeServer(t *testing.T) {
+	testCases := map[string]struct {
+		status       int
+		body         string
+		wantVersion  string
+		wantRequests int
+	}{
+		"version": {
+			status:       http.StatusOK,
+			body:         `{"status": "ok", "version": "1.22.5"}`,
+			wantVersion:  "1.22.5",
+			wantRequests: 1,
+		},
+		"unavailable": {
+			status:       http.StatusNotFound,
+			body:         `{"error": "Not Found"}`,
+			wantVersion:  "unknown",
+			wantRequests: 1,
+		},
+		"server error": {
+			status:       http.StatusServiceUnavailable,
+			body:         `{"error": "Service Unavailable"}`,
+			wantVersion:  "unknown",
+			wantRequests: 1,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var requests int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if r.URL.Path != "/health" {
+					t.Errorf("unexpected request to %s", r.URL.Path)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(testCase.status)
+				_, _ = io.WriteString(w, testCase.body)
+			}))
+			defer server.Close()
+
+			info := probeServer(context.Background(), clientConfig{host: server.URL, maxRetries: 3, maxBackoff: time.Millisecond})
+			if got := info.versionString(); got != testCase.wantVersion {
+				t.Errorf("got version %s, want %s", got, testCase.wantVersion)
+			}
+			if requests != testCase.wantRequests {
+				t.Errorf("got %d requests, want %d", requests, testCase.wantRequests)
+			}
+		})
+	}
+}
+
+func TestServerInfoValidateConfig(t *testing.T) {
+	serverRequirements["test_resource"] = []serverRequirement{
+		{attribute: "new_attribute", minVersion: version.Must(version.NewVersion("1.23.0"))},
+		{attribute: "other_attribute", minVersion: version.Must(version.NewVersion("1.23.0"))},
+	}
+	defer delete(serverRequirements, "test_resource")
+
+	testSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"new_attribute":   schema.StringAttribute{Optional: true},
+			"other_attribute": schema.StringAttribute{Optional: true},
+		},
+	}
+	config := func(newAttribute, otherAttribute any) tfsdk.Config {
+		return tfsdk.Config{
+			Schema: testSchema,
+			Raw: tftypes.NewValue(tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+				"new_attribute":   tftypes.String,
+				"other_attribute": tftypes.String,
+			}}, map[string]tftypes.Value{
+				"new_attribute":   tftypes.NewValue(tftypes.String, newAttribute),
+				"other_attribute": tftypes.NewValue(tftypes.String, otherAttribute),
+			}),
+		}
+	}
+
+	oldServer := &serverInfo{version: version.Must(version.NewVersion("1.22.5"))}
+
+	testCases := map[string]struct {
+		server     *serverInfo
+		config     tfsdk.Config
+		wantErrors int
+	}{
+		"unset attributes": {
+			server: oldServer,
+			config: config(nil, nil),
+		},
+		"unsupported attributes": {
+			server:     oldServer,
+			config:     config("value", "value"),
+			wantErrors: 2,
+		},
+		"supported attributes": {
+			server: &serverInfo{version: version.Must(version.NewVersion("1.23.3"))},
+			config: config("value", "value"),
+		},
+		"unknown server": {
+			server: nil,
+			config: config("value", "value"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			diags := testCase.server.validateConfig(context.Background(), "test_resource", testCase.config)
+			if got := diags.ErrorsCount(); got != testCase.wantErrors {
+				t.Errorf("got %d errors, want %d: %v", got, testCase.wantErrors, diags)
+			}
+		})
+	}
+}
+
+func TestEndpointResourceModifyPlanOldServer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"status": "ok", "version": "1.22.5"}`)
+	}))
+	defer server.Close()
+
+	diags := planTestEndpoint(t, &EndpointResource{server: probeServer(context.Background(), clientConfig{host: server.URL})}, EndpointResourceModel{
+		Language: types.StringValue("pg_sql"),
+	})
+	if diags.ErrorsCount() != 1 || diags.Errors()[0].Summary() != "Unsupported Attribute" {
+		t.Errorf("expected language to be reported as unsupported, got %v", diags)
+	}
+}
+
+// planTestEndpoint plans the creation of an endpoint named errors from data,
+// which is used as both configuration and plan, and returns the plan
+// diagnostics. The query defaults to a fixed one.
+func planTestEndpoint(t *testing.T, r *EndpointResource, data EndpointResourceModel) diag.Diagnostics {
+	t.Helper()
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+
+	data.Name = types.StringValue("errors")
+	if data.Query.IsNull() {
+		data.Query = NewSQLQueryValue("select 1")
+	}
+	data.Timeouts = nullTimeouts()
+	if diags := state.Set(ctx, &data); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	plan := tfsdk.Plan{Schema: state.Schema, Raw: state.Raw}
+	req := resource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: state.Schema, Raw: state.Raw},
+		Plan:   plan,
+		State:  tfsdk.State{Schema: state.Schema, Raw: tftypes.NewValue(state.Raw.Type(), nil)},
+	}
+	resp := resource.ModifyPlanResponse{Plan: plan}
+	r.ModifyPlan(ctx, req, &resp)
+
+	return resp.Diagnostics
+}
@@ -0,0 +1,103 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/supabase/terraform-provider-supabase-analytics/internal/pkg/api"
+)
+
+// sourceBackendTokens returns the tokens of the backends attached to a source.
+func sourceBackendTokens(result *api.Source) []string {
+	tokens := []string{}
+	if result.Backends == nil {
+		return tokens
+	}
+
+	for _, backend := range *result.Backends {
+		if backend.Token != nil {
+			tokens = append(tokens, *backend.Token)
+		}
+	}
+
+	return tokens
+}
+
+// syncSourceBackends attaches and detaches backends until the source has
+// exactly the desired ones, then reads the source back into data. The source
+// is read back even when an attachment fails, so that data holds the ones
+// already changed. Nothing is done when desired is null, as the attachments
+// are then not managed.
+func syncSourceBackends(ctx context.Context, data *SourceResourceModel, desired types.Set, client *api.ClientWithResponses) diag.Diagnostics {
+	if desired.IsNull() || desired.IsUnknown() {
+		return nil
+	}
+
+	var desiredTokens []string
+	if diags := desired.ElementsAs(ctx, &desiredTokens, false); diags.HasError() {
+		return diags
+	}
+
+	diags := changeSourceBackends(ctx, data.Token.ValueString(), desiredTokens, client)
+
+	return append(diags, readSource(ctx, data, client)...)
+}
+
+// changeSourceBackends attaches the desired backends that the source lacks
+// and detaches the ones that are not desired, stopping at the first failure.
+func changeSourceBackends(ctx context.Context, sourceToken string, desiredTokens []string, client *api.ClientWithResponses) diag.Diagnostics {
+	httpResp, err := client.LogflareWebApiSourceControllerShowWithResponse(ctx, sourceToken)
+	if err != nil {
+		msg := fmt.Sprintf("Unable to read source backends, got error: %s", err)
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", msg)}
+	}
+
+	if httpResp.JSON200 == nil {
+		msg := fmt.Sprintf("Unable to read source backends, got status %d: %s", httpResp.StatusCode(), httpResp.Body)
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", msg)}
+	}
+
+	currentTokens := sourceBackendTokens(httpResp.JSON200)
+
+	for _, backendToken := range desiredTokens {
+		if slices.Contains(currentTokens, backendToken) {
+			continue
+		}
+
+		addResp, err := client.LogflareWebApiSourceControllerAddBackendWithResponse(ctx, sourceToken, backendToken)
+		if err != nil {
+			msg := fmt.Sprintf("Unable to attach backend %s, got error: %s", backendToken, err)
+			return diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", msg)}
+		}
+
+		if addResp.StatusCode() < 200 || addResp.StatusCode() >= 300 {
+			msg := fmt.Sprintf("Unable to attach backend %s, got status %d: %s", backendToken, addResp.StatusCode(), addResp.Body)
+			return diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", msg)}
+		}
+	}
+
+	for _, backendToken := range currentTokens {
+		if slices.Contains(desiredTokens, backendToken) {
+			continue
+		}
+
+		removeResp, err := client.LogflareWebApiSourceControllerRemoveBackendWithResponse(ctx, sourceToken, backendToken)
+		if err != nil {
+			msg := fmt.Sprintf("Unable to detach backend %s, got error: %s", backendToken, err)
+			return diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", msg)}
+		}
+
+		if removeResp.StatusCode() < 200 || removeResp.StatusCode() >= 300 {
+			msg := fmt.Sprintf("Unable to detach backend %s, got status %d: %s", backendToken, removeResp.StatusCode(), removeResp.Body)
+			return diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", msg)}
+		}
+	}
+
+	return nil
+}
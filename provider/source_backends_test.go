@@ -0,0 +1,154 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSyncSourceBackends(t *testing.T) {
+	var mu sync.Mutex
+	attached := []string{"kept", "removed"}
+	var requests []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if backendToken, ok := strings.CutPrefix(r.URL.Path, "/api/sources/source-token/backends/"); ok {
+			requests = append(requests, r.Method+" "+backendToken)
+			switch r.Method {
+			case http.MethodPost:
+				attached = append(attached, backendToken)
+				w.WriteHeader(http.StatusCreated)
+			case http.MethodDelete:
+				attached = slices.DeleteFunc(attached, func(token string) bool { return token == backendToken })
+				w.WriteHeader(http.StatusOK)
+			}
+			_, _ = w.Write([]byte(`{"name": "my-source"}`))
+			return
+		}
+
+		backends := []map[string]string{}
+		for _, token := range attached {
+			backends = append(backends, map[string]string{"name": token, "token": token})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": 1, "name": "my-source", "token": "source-token", "backends": backends})
+	}))
+	defer server.Close()
+
+	client, err := newLogflareClient(clientConfig{host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	data := SourceResourceModel{
+		BackendTokens: types.SetValueMust(types.StringType, nil),
+		Token:         types.StringValue("source-token"),
+	}
+	desired := types.SetValueMust(types.StringType, []attr.Value{types.StringValue("kept"), types.StringValue("added")})
+
+	if diags := syncSourceBackends(ctx, &data, desired, client); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if !slices.Equal(requests, []string{"POST added", "DELETE removed"}) {
+		t.Errorf("unexpected requests %q", requests)
+	}
+	if !data.BackendTokens.Equal(desired) {
+		t.Errorf("expected backend tokens %s to be read back, got %s", desired, data.BackendTokens)
+	}
+
+	requests = nil
+	if diags := syncSourceBackends(ctx, &data, types.SetNull(types.StringType), client); diags.HasError() || len(requests) != 0 {
+		t.Errorf("expected unmanaged backends to be left alone, got %q and %v", requests, diags)
+	}
+}
+
+func TestSourceResourceUpdateDetachFailure(t *testing.T) {
+	var mu sync.Mutex
+	attached := []string{"kept", "removed"}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if backendToken, ok := strings.CutPrefix(r.URL.Path, "/api/sources/source-token/backends/"); ok {
+			if r.Method == http.MethodDelete {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				_, _ = w.Write([]byte(`{"error": "backend is in use"}`))
+				return
+			}
+			attached = append(attached, backendToken)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"name": "my-source"}`))
+			return
+		}
+
+		backends := []map[string]string{}
+		for _, token := range attached {
+			backends = append(backends, map[string]string{"name": token, "token": token})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": 1, "name": "my-source", "token": "source-token", "backends": backends})
+	}))
+	defer server.Close()
+
+	client, err := newLogflareClient(clientConfig{host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	r := &SourceResource{client: client, names: &nameIndex{}}
+
+	state, identity := emptyTestState(t, r)
+
+	// Read the source to fill in every attribute of the prior state.
+	data := SourceResourceModel{
+		BackendTokens: types.SetValueMust(types.StringType, nil),
+		Token:         types.StringValue("source-token"),
+		Timeouts:      nullTimeouts(),
+	}
+	if diags := readSource(ctx, &data, client); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if diags := state.Set(ctx, &data); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	plan := tfsdk.Plan{Schema: state.Schema, Raw: state.Raw}
+	data.BackendTokens = types.SetValueMust(types.StringType, []attr.Value{types.StringValue("kept"), types.StringValue("added")})
+	if diags := plan.Set(ctx, &data); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	resp := resource.UpdateResponse{State: state, Identity: identity}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: state, Identity: identity}, &resp)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error for the failed detach")
+	}
+
+	var backendTokens types.Set
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("backend_tokens"), &backendTokens)...)
+	want := types.SetValueMust(types.StringType, []attr.Value{types.StringValue("kept"), types.StringValue("removed"), types.StringValue("added")})
+	if !backendTokens.Equal(want) {
+		t.Errorf("expected the attachments on the server %s in state, got %s", want, backendTokens)
+	}
+}
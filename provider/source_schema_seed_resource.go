@@ -0,0 +1,400 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/supabase/terraform-provider-supabase-analytics/internal/pkg/api"
+)
+
+// sourceSchemaPollInterval is how long to wait between show_schema requests
+// while waiting for ingested sample events to show up in the source schema.
+var sourceSchemaPollInterval = 2 * time.Second
+
+var (
+	_ resource.Resource                 = &SourceSchemaSeedResource{}
+	_ resource.ResourceWithUpgradeState = &SourceSchemaSeedResource{}
+)
+
+func NewSourceSchemaSeedResource() resource.Resource {
+	return &SourceSchemaSeedResource{}
+}
+
+// SourceSchemaSeedResource ingests sample events into a source so that its
+// schema contains the expected fields before dependent endpoints are created.
+type SourceSchemaSeedResource struct {
+	client *api.ClientWithResponses
+}
+
+type SourceSchemaSeedResourceModel struct {
+	Events         types.List           `tfsdk:"events"`
+	ExpectedFields types.Set            `tfsdk:"expected_fields"`
+	Schema         jsontypes.Normalized `tfsdk:"schema"`
+	SourceToken    types.String         `tfsdk:"source_token"`
+	Timeouts       timeouts.Value       `tfsdk:"timeouts"`
+}
+
+func (r *SourceSchemaSeedResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_source_schema_seed"
+}
+
+func (r *SourceSchemaSeedResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Seeds the schema of a source by ingesting sample events and waiting for their fields to appear. " +
+			"Use it to let endpoints that reference source fields be created in the same apply as the source. " +
+			"The create and update timeouts bound how long to wait for the fields.",
+		Version: int64(len(sourceSchemaSeedStateUpgradeSteps)),
+		Attributes: map[string]schema.Attribute{
+			"source_token": schema.StringAttribute{
+				Description: "Token of the source to seed.",
+				Required:    true,
+				Sensitive:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"events": schema.ListAttribute{
+				Description: "Sample events to ingest, each as a JSON object string. Events are ingested again whenever this list changes.",
+				Required:    true,
+				ElementType: jsontypes.NormalizedType{},
+			},
+			"expected_fields": schema.SetAttribute{
+				Description: "Dot separated field paths to wait for, e.g. `metadata.user_id`. Defaults to every field of the sample events.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"schema": schema.StringAttribute{
+				Description: "Source schema as reported by the server, as a JSON string.",
+				Computed:    true,
+				CustomType:  jsontypes.NormalizedType{},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *SourceSchemaSeedResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return stateUpgraders(sourceSchemaSeedStateUpgradeSteps...)
+}
+
+// sourceSchemaSeedStateUpgradeSteps upgrade the state of
+// logflare_source_schema_seed one schema version at a time. The schema
+// version is the number of steps.
+var sourceSchemaSeedStateUpgradeSteps = []stateUpgradeStep{
+	// Version 1 replaced timeout_seconds with the create and update timeouts,
+	// which now bound the wait for the expected fields.
+	func(state map[string]any) error {
+		delete(state, "timeout_seconds")
+		return nil
+	},
+}
+
+func (r *SourceSchemaSeedResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*logflareProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *logflareProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.client
+}
+
+func (r *SourceSchemaSeedResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SourceSchemaSeedResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(seedSourceSchema(ctx, &data, r.client, "create", createTimeout)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SourceSchemaSeedResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SourceSchemaSeedResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	httpResp, err := r.client.LogflareWebApiSourceControllerShowSchemaWithResponse(ctx, data.SourceToken.ValueString())
+	if err != nil {
+		diags := diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", fmt.Sprintf("Unable to read source schema, got error: %s", err))}
+		resp.Diagnostics.Append(withTimeoutDiagnostics(ctx, diags, "read", "logflare_source_schema_seed", readTimeout)...)
+		return
+	}
+
+	if httpResp.StatusCode() == 404 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if httpResp.JSON200 == nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read source schema, got status %d: %s", httpResp.StatusCode(), httpResp.Body))
+		return
+	}
+
+	resp.Diagnostics.Append(sourceSchemaToSeedModel(*httpResp.JSON200, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SourceSchemaSeedResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state SourceSchemaSeedResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Events.Equal(state.Events) && data.ExpectedFields.Equal(state.ExpectedFields) {
+		data.Schema = state.Schema
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(seedSourceSchema(ctx, &data, r.client, "update", updateTimeout)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SourceSchemaSeedResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+	// Ingested events cannot be removed from a source, so there is nothing to
+	// clean up beyond dropping the resource from state.
+}
+
+// seedSourceSchema ingests the sample events and waits until ctx is done for
+// their fields to appear. Running out of time while waiting is reported with
+// the fields still missing rather than as a generic operation timeout.
+func seedSourceSchema(ctx context.Context, data *SourceSchemaSeedResourceModel, client *api.ClientWithResponses, operation string, timeout time.Duration) diag.Diagnostics {
+	events, diags := seedModelToEvents(ctx, data)
+	if diags.HasError() {
+		return diags
+	}
+
+	var fields []string
+	if data.ExpectedFields.IsNull() {
+		fields = sampleEventFieldPaths(events)
+	} else {
+		diags.Append(data.ExpectedFields.ElementsAs(ctx, &fields, false)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	token := data.SourceToken.ValueString()
+	diags.Append(withTimeoutDiagnostics(ctx, ingestSourceEvents(ctx, client, token, events), operation, "logflare_source_schema_seed", timeout)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	sourceSchema, waitDiags := waitForSourceSchemaFields(ctx, client, token, fields, operation, timeout)
+	diags.Append(waitDiags...)
+	if diags.HasError() {
+		return diags
+	}
+
+	diags.Append(sourceSchemaToSeedModel(sourceSchema, data)...)
+	return diags
+}
+
+func ingestSourceEvents(ctx context.Context, client *api.ClientWithResponses, token string, events []map[string]any) diag.Diagnostics {
+	params := api.LogflareWebLogControllerCreate4Params{Source: &token}
+	httpResp, err := client.LogflareWebLogControllerCreate4WithResponse(ctx, &params, api.LogsBatch{Batch: &events})
+	if err != nil {
+		msg := fmt.Sprintf("Unable to ingest sample events, got error: %s", err)
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", msg)}
+	}
+
+	if httpResp.StatusCode() < 200 || httpResp.StatusCode() >= 300 {
+		msg := fmt.Sprintf("Unable to ingest sample events, got status %d: %s", httpResp.StatusCode(), httpResp.Body)
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", msg)}
+	}
+
+	tflog.Debug(ctx, "Ingested sample events", map[string]any{"count": len(events)})
+
+	return nil
+}
+
+// waitForSourceSchemaFields polls show_schema until every field is present in
+// the source schema or ctx is done, returning the schema that has them all.
+// A schema that lacks some of the fields is the only response waited on; any
+// other response is reported right away. When ctx is done, the fields missing
+// from the last schema read are reported, even if the final poll failed.
+func waitForSourceSchemaFields(ctx context.Context, client *api.ClientWithResponses, token string, fields []string, operation string, timeout time.Duration) (api.SourceSchema, diag.Diagnostics) {
+	ticker := time.NewTicker(sourceSchemaPollInterval)
+	defer ticker.Stop()
+
+	missing := slices.Sorted(slices.Values(fields))
+	for {
+		httpResp, err := client.LogflareWebApiSourceControllerShowSchemaWithResponse(ctx, token)
+		if err != nil && ctx.Err() == nil {
+			msg := fmt.Sprintf("Unable to read source schema, got error: %s", err)
+			return nil, diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", msg)}
+		}
+
+		if httpResp != nil && httpResp.JSON200 == nil {
+			msg := fmt.Sprintf("Unable to read source schema, got status %d: %s", httpResp.StatusCode(), httpResp.Body)
+			return nil, diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", msg)}
+		}
+
+		if httpResp != nil {
+			missing = missingSourceSchemaFields(*httpResp.JSON200, fields)
+			if len(missing) == 0 {
+				return *httpResp.JSON200, nil
+			}
+			tflog.Debug(ctx, "Waiting for source schema fields", map[string]any{"missing": missing})
+		}
+
+		select {
+		case <-ctx.Done():
+			msg := fmt.Sprintf("Timed out after %s waiting for fields to appear in the source schema: %s. "+
+				"Raise timeouts.%s in the resource configuration if the server needs longer to update the schema.",
+				timeout, strings.Join(missing, ", "), operation)
+			return nil, diag.Diagnostics{diag.NewErrorDiagnostic("Source Schema Not Ready", msg)}
+		case <-ticker.C:
+		}
+	}
+}
+
+// missingSourceSchemaFields returns the dot separated field paths that are
+// not present in the source schema.
+func missingSourceSchemaFields(sourceSchema api.SourceSchema, fields []string) []string {
+	present := map[string]bool{}
+	collectFieldPaths("", sourceSchema, present)
+
+	var missing []string
+	for _, field := range fields {
+		if !present[field] {
+			missing = append(missing, field)
+		}
+	}
+	slices.Sort(missing)
+
+	return missing
+}
+
+// sampleEventFieldPaths returns the dot separated paths of all fields set in
+// the sample events.
+func sampleEventFieldPaths(events []map[string]any) []string {
+	present := map[string]bool{}
+	for _, event := range events {
+		collectFieldPaths("", event, present)
+	}
+
+	fields := make([]string, 0, len(present))
+	for field := range present {
+		fields = append(fields, field)
+	}
+	slices.Sort(fields)
+
+	return fields
+}
+
+func collectFieldPaths(prefix string, m map[string]any, paths map[string]bool) {
+	for key, value := range m {
+		field := prefix + key
+		paths[field] = true
+		if nested, ok := value.(map[string]any); ok {
+			collectFieldPaths(field+".", nested, paths)
+		}
+	}
+}
+
+func seedModelToEvents(ctx context.Context, data *SourceSchemaSeedResourceModel) ([]map[string]any, diag.Diagnostics) {
+	var values []jsontypes.Normalized
+	diags := data.Events.ElementsAs(ctx, &values, false)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	events := make([]map[string]any, 0, len(values))
+	for i, value := range values {
+		var event map[string]any
+		if err := json.Unmarshal([]byte(value.ValueString()), &event); err != nil || event == nil {
+			diags.AddAttributeError(
+				path.Root("events").AtListIndex(i),
+				"Invalid Sample Event",
+				"Each sample event must be a JSON object.",
+			)
+			continue
+		}
+		events = append(events, event)
+	}
+
+	return events, diags
+}
+
+func sourceSchemaToSeedModel(sourceSchema api.SourceSchema, data *SourceSchemaSeedResourceModel) diag.Diagnostics {
+	value, err := json.Marshal(sourceSchema)
+	if err != nil {
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Can't encode 'schema' field", err.Error())}
+	}
+	data.Schema = jsontypes.NewNormalizedValue(string(value))
+
+	return nil
+}
@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSourceSchemaSeedResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccSourceSchemaSeedResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("logflare_source_schema_seed.seed_test", "events.#", "1"),
+					resource.TestCheckResourceAttrSet("logflare_source_schema_seed.seed_test", "schema"),
+					resource.TestCheckResourceAttr("logflare_endpoint.seed_endpoint_test", "name", "my_seeded_endpoint"),
+				),
+			},
+		},
+	})
+}
+
+func TestMissingSourceSchemaFields(t *testing.T) {
+	events := []map[string]any{
+		{"event_message": "hello", "metadata": map[string]any{"user_id": "123"}},
+	}
+	fields := sampleEventFieldPaths(events)
+	if !slices.Equal(fields, []string{"event_message", "metadata", "metadata.user_id"}) {
+		t.Fatalf("unexpected sample event fields: %v", fields)
+	}
+
+	sourceSchema := map[string]any{
+		"event_message": "string",
+		"metadata":      map[string]any{"region": "string"},
+	}
+	missing := missingSourceSchemaFields(sourceSchema, fields)
+	if !slices.Equal(missing, []string{"metadata.user_id"}) {
+		t.Fatalf("unexpected missing fields: %v", missing)
+	}
+}
+
+func TestWaitForSourceSchemaFieldsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = io.WriteString(w, `{"error": "Unauthorized"}`)
+	}))
+	defer server.Close()
+
+	client, err := newLogflareClient(clientConfig{host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	_, diags := waitForSourceSchemaFields(ctx, client, "source-token", []string{"event_message"}, "create", time.Minute)
+	if !diags.HasError() || !strings.Contains(diags[0].Detail(), "401") || !strings.Contains(diags[0].Detail(), "Unauthorized") {
+		t.Errorf("expected an error with the status and body, got %v", diags)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("expected the error to be reported without waiting, took %s", elapsed)
+	}
+}
+
+func TestWaitForSourceSchemaFieldsTimeout(t *testing.T) {
+	defer func(interval time.Duration) { sourceSchemaPollInterval = interval }(sourceSchemaPollInterval)
+	sourceSchemaPollInterval = 10 * time.Millisecond
+
+	var polls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Only the first poll is answered; the next one is still pending
+		// when the deadline passes.
+		if polls.Add(1) > 1 {
+			<-r.Context().Done()
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"event_message": "string"}`)
+	}))
+	defer server.Close()
+
+	client, err := newLogflareClient(clientConfig{host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	_, diags := waitForSourceSchemaFields(ctx, client, "source-token", []string{"event_message", "metadata.user_id"}, "create", time.Minute)
+	if !diags.HasError() || diags[0].Summary() != "Source Schema Not Ready" {
+		t.Fatalf("expected a timeout error, got %v", diags)
+	}
+	if detail := diags[0].Detail(); !strings.Contains(detail, ": metadata.user_id.") || !strings.Contains(detail, "timeouts.create") {
+		t.Errorf("expected only the field missing from the last schema to be reported, got %q", detail)
+	}
+}
+
+const testAccSourceSchemaSeedResourceConfig = `
+resource "logflare_source" "seed_source_test" {
+	name = "my-seeded-source"
+	deletion_protection = false
+}
+
+resource "logflare_source_schema_seed" "seed_test" {
+	source_token = logflare_source.seed_source_test.token
+	events = [
+		jsonencode({ event_message = "hello", metadata = { user_id = "123" } }),
+	]
+}
+
+resource "logflare_endpoint" "seed_endpoint_test" {
+	name  = "my_seeded_endpoint"
+	query = "select m.user_id from my_seeded_source t cross join unnest(t.metadata) as m"
+	source_mapping = jsonencode({
+		my_seeded_source = logflare_source.seed_source_test.token
+	})
+
+	depends_on = [logflare_source_schema_seed.seed_test]
+}
+`
@@ -0,0 +1,152 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/supabase/terraform-provider-supabase-analytics/internal/pkg/api"
+)
+
+var (
+	_ list.ListResource              = &SourceListResource{}
+	_ list.ListResourceWithConfigure = &SourceListResource{}
+)
+
+func NewSourceListResource() list.ListResource {
+	return &SourceListResource{}
+}
+
+// SourceListResource lists existing sources for `terraform query`.
+type SourceListResource struct {
+	client *api.ClientWithResponses
+}
+
+type SourceListResourceModel struct {
+	Favorite  types.Bool   `tfsdk:"favorite"`
+	NameRegex types.String `tfsdk:"name_regex"`
+}
+
+func (r *SourceListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_source"
+}
+
+func (r *SourceListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		Description: "Lists sources.",
+		Attributes: map[string]listschema.Attribute{
+			"favorite": listschema.BoolAttribute{
+				Description: "Only list sources with this favorite setting.",
+				Optional:    true,
+			},
+			"name_regex": listschema.StringAttribute{
+				Description: "Only list sources whose name matches this regular expression.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (r *SourceListResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.ClientWithResponses)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected *api.ClientWithResponses, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *SourceListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config SourceListResourceModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	nameRegex, diags := compileNameRegex(config.NameRegex)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	httpResp, err := r.client.LogflareWebApiSourceControllerIndexWithResponse(ctx)
+	if err != nil {
+		msg := fmt.Sprintf("Unable to list sources, got error: %s", err)
+		stream.Results = list.ListResultsStreamDiagnostics(diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", msg)})
+		return
+	}
+
+	if httpResp.JSON200 == nil {
+		msg := fmt.Sprintf("Unable to list sources, got status %d: %s", httpResp.StatusCode(), httpResp.Body)
+		stream.Results = list.ListResultsStreamDiagnostics(diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", msg)})
+		return
+	}
+
+	sources := *httpResp.JSON200
+	stream.Results = func(push func(list.ListResult) bool) {
+		var count int64
+		for _, source := range sources {
+			if req.Limit > 0 && count >= req.Limit {
+				return
+			}
+			if nameRegex != nil && !nameRegex.MatchString(source.Name) {
+				continue
+			}
+			if !config.Favorite.IsNull() && config.Favorite.ValueBool() != (source.Favorite != nil && *source.Favorite) {
+				continue
+			}
+
+			result := req.NewListResult(ctx)
+			result.DisplayName = source.Name
+			result.Diagnostics.Append(result.Identity.Set(ctx, tokenIdentityModel{Token: types.StringPointerValue(source.Token)})...)
+
+			if req.IncludeResource {
+				data := SourceResourceModel{DeletionProtection: types.BoolValue(sourceDeletionProtectionDefault), Timeouts: nullTimeouts()}
+				result.Diagnostics.Append(sourceSchemaToModel(ctx, &source, &data)...)
+				if !result.Diagnostics.HasError() {
+					result.Diagnostics.Append(result.Resource.Set(ctx, &data)...)
+				}
+			}
+
+			count++
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
+
+// compileNameRegex compiles the optional name_regex filter of a list resource.
+func compileNameRegex(value types.String) (*regexp.Regexp, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if value.IsNull() || value.IsUnknown() {
+		return nil, diags
+	}
+
+	re, err := regexp.Compile(value.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("name_regex"), "Invalid Regular Expression", err.Error())
+		return nil, diags
+	}
+
+	return re, diags
+}
@@ -0,0 +1,55 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/querycheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccSourcesListResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccSourcesListResourceConfig,
+			},
+			{
+				Query:  true,
+				Config: providerConfig + testAccSourcesListResourceQuery,
+				QueryResultChecks: []querycheck.QueryResultCheck{
+					querycheck.ExpectLength("logflare_source.listed", 1),
+					querycheck.ExpectLengthAtLeast("logflare_source.all", 1),
+				},
+			},
+		},
+	})
+}
+
+const testAccSourcesListResourceConfig = `
+resource "logflare_source" "source_list_test" {
+	name = "my-listed-source"
+	deletion_protection = false
+}
+`
+
+const testAccSourcesListResourceQuery = `
+list "logflare_source" "listed" {
+	provider = logflare
+
+	config {
+		name_regex = "^my-listed-source$"
+	}
+}
+
+list "logflare_source" "all" {
+	provider = logflare
+}
+`
@@ -7,24 +7,41 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"regexp"
+	"slices"
+	"strings"
 	"time"
 
-	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/supabase/terraform-provider-supabase-analytics/internal/pkg/api"
 )
 
 var (
-	_ resource.Resource = &SourceResource{}
+	_ resource.Resource                 = &SourceResource{}
+	_ resource.ResourceWithIdentity     = &SourceResource{}
+	_ resource.ResourceWithImportState  = &SourceResource{}
+	_ resource.ResourceWithModifyPlan   = &SourceResource{}
+	_ resource.ResourceWithUpgradeState = &SourceResource{}
+	_ resource.ResourceWithMoveState    = &SourceResource{}
 )
 
 func NewSourceResource() resource.Resource {
@@ -33,33 +50,67 @@ func NewSourceResource() resource.Resource {
 
 type SourceResource struct {
 	client *api.ClientWithResponses
+	names  *nameIndex
+	server *serverInfo
 }
 
 type SourceResourceModel struct {
-	ApiQuota                    types.Int32          `tfsdk:"api_quota"`
-	BigqueryTableTtl            types.Int32          `tfsdk:"bigquery_table_ttl"`
-	BqTableId                   types.String         `tfsdk:"bq_table_id"`
-	CustomEventMessageKeys      types.String         `tfsdk:"custom_event_message_keys"`
-	DefaultIngestBackendEnabled types.Bool           `tfsdk:"default_ingest_backend_enabled"`
-	Favorite                    types.Bool           `tfsdk:"favorite"`
-	HasRejectedEvents           types.Bool           `tfsdk:"has_rejected_events"`
-	Id                          types.Int64          `tfsdk:"id"`
-	InsertedAt                  types.String         `tfsdk:"inserted_at"`
-	Metrics                     jsontypes.Normalized `tfsdk:"metrics"`
-	Name                        types.String         `tfsdk:"name"`
-	Notifications               types.Object         `tfsdk:"notifications"`
-	PublicToken                 types.String         `tfsdk:"public_token"`
-	SlackHookUrl                types.String         `tfsdk:"slack_hook_url"`
-	Token                       types.String         `tfsdk:"token"`
-	UpdatedAt                   types.String         `tfsdk:"updated_at"`
-	WebhookNotificationUrl      types.String         `tfsdk:"webhook_notification_url"`
+	ApiQuota                              types.Int32    `tfsdk:"api_quota"`
+	BackendTokens                         types.Set      `tfsdk:"backend_tokens"`
+	BigqueryClusteringFields              types.List     `tfsdk:"bigquery_clustering_fields"`
+	BigqueryTableTtl                      types.Int32    `tfsdk:"bigquery_table_ttl"`
+	BqTableId                             types.String   `tfsdk:"bq_table_id"`
+	CustomEventMessageKeys                types.String   `tfsdk:"custom_event_message_keys"`
+	DefaultIngestBackendEnabled           types.Bool     `tfsdk:"default_ingest_backend_enabled"`
+	DeletionProtection                    types.Bool     `tfsdk:"deletion_protection"`
+	DeletionProtectionIngestWindowSeconds types.Int32    `tfsdk:"deletion_protection_ingest_window_seconds"`
+	DropLqlFilters                        types.String   `tfsdk:"drop_lql_filters"`
+	Favorite                              types.Bool     `tfsdk:"favorite"`
+	HasRejectedEvents                     types.Bool     `tfsdk:"has_rejected_events"`
+	Id                                    types.Int64    `tfsdk:"id"`
+	InsertedAt                            types.String   `tfsdk:"inserted_at"`
+	LockSchema                            types.Bool     `tfsdk:"lock_schema"`
+	Metrics                               types.Object   `tfsdk:"metrics"`
+	Name                                  types.String   `tfsdk:"name"`
+	Notifications                         types.Object   `tfsdk:"notifications"`
+	PublicToken                           types.String   `tfsdk:"public_token"`
+	RetentionDays                         types.Int32    `tfsdk:"retention_days"`
+	SlackHookUrl                          types.String   `tfsdk:"slack_hook_url"`
+	SuggestedKeys                         types.Set      `tfsdk:"suggested_keys"`
+	Token                                 types.String   `tfsdk:"token"`
+	TransformCopyFields                   types.List     `tfsdk:"transform_copy_fields"`
+	UpdatedAt                             types.String   `tfsdk:"updated_at"`
+	ValidateSchema                        types.Bool     `tfsdk:"validate_schema"`
+	WebhookNotificationUrl                types.String   `tfsdk:"webhook_notification_url"`
+	Timeouts                              timeouts.Value `tfsdk:"timeouts"`
+}
+
+// SourceMetricsModel holds the ingest metrics reported by the server.
+type SourceMetricsModel struct {
+	Avg     types.Float64 `tfsdk:"avg"`
+	Buffer  types.Int64   `tfsdk:"buffer"`
+	Inserts types.Int64   `tfsdk:"inserts"`
+	Max     types.Int64   `tfsdk:"max"`
+	Rate    types.Int64   `tfsdk:"rate"`
+	Recent  types.Int64   `tfsdk:"recent"`
+}
+
+func (m SourceMetricsModel) AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"avg":     types.Float64Type,
+		"buffer":  types.Int64Type,
+		"inserts": types.Int64Type,
+		"max":     types.Int64Type,
+		"rate":    types.Int64Type,
+		"recent":  types.Int64Type,
+	}
 }
 
 type NotificationModel struct {
 	OtherEmailNotifications       types.String `tfsdk:"other_email_notifications"`
-	TeamUserIdsForEmail           types.List   `tfsdk:"team_user_ids_for_email"`
-	TeamUserIdsForSchemaUpdates   types.List   `tfsdk:"team_user_ids_for_schema_updates"`
-	TeamUserIdsForSms             types.List   `tfsdk:"team_user_ids_for_sms"`
+	TeamUserIdsForEmail           types.Set    `tfsdk:"team_user_ids_for_email"`
+	TeamUserIdsForSchemaUpdates   types.Set    `tfsdk:"team_user_ids_for_schema_updates"`
+	TeamUserIdsForSms             types.Set    `tfsdk:"team_user_ids_for_sms"`
 	UserEmailNotifications        types.Bool   `tfsdk:"user_email_notifications"`
 	UserSchemaUpdateNotifications types.Bool   `tfsdk:"user_schema_update_notifications"`
 	UserTextNotifications         types.Bool   `tfsdk:"user_text_notifications"`
@@ -68,9 +119,9 @@ type NotificationModel struct {
 func (m NotificationModel) AttributeTypes() map[string]attr.Type {
 	return map[string]attr.Type{
 		"other_email_notifications":        types.StringType,
-		"team_user_ids_for_email":          types.ListType{ElemType: types.StringType},
-		"team_user_ids_for_schema_updates": types.ListType{ElemType: types.StringType},
-		"team_user_ids_for_sms":            types.ListType{ElemType: types.StringType},
+		"team_user_ids_for_email":          types.SetType{ElemType: types.StringType},
+		"team_user_ids_for_schema_updates": types.SetType{ElemType: types.StringType},
+		"team_user_ids_for_sms":            types.SetType{ElemType: types.StringType},
 		"user_email_notifications":         types.BoolType,
 		"user_schema_update_notifications": types.BoolType,
 		"user_text_notifications":          types.BoolType,
@@ -81,9 +132,10 @@ func (r *SourceResource) Metadata(_ context.Context, req resource.MetadataReques
 	resp.TypeName = req.ProviderTypeName + "_source"
 }
 
-func (r *SourceResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *SourceResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages a Source resource.",
+		Version:     int64(len(sourceStateUpgradeSteps)),
 		Attributes: map[string]schema.Attribute{
 			"id": schema.Int64Attribute{
 				Computed:            true,
@@ -102,6 +154,22 @@ func (r *SourceResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				Computed:    true,
 				Default:     int32default.StaticInt32(25),
 			},
+			"backend_tokens": schema.SetAttribute{
+				Description: "Tokens of the backends attached to the source. Attachments are reconciled with the server on every apply, " +
+					"and attachments made elsewhere show up as drift. Leave unset to manage attachments outside of this resource.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"bigquery_clustering_fields": schema.ListAttribute{
+				Description: "Fields to cluster the BigQuery table by, in order of precedence. At most four fields.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeBetween(1, 4),
+					listvalidator.UniqueValues(),
+					listvalidator.ValueStringsAre(stringvalidator.RegexMatches(sourceFieldPathPattern, "must be a field path such as metadata.user_id")),
+				},
+			},
 			"bigquery_table_ttl": schema.Int32Attribute{
 				Description: "BigQuery table Time-To-Live (TTL) in days.",
 				Optional:    true,
@@ -122,6 +190,28 @@ func (r *SourceResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				Optional:    true,
 				Computed:    true,
 			},
+			"deletion_protection": schema.BoolAttribute{
+				Description: "Whether to refuse deleting the source, which drops its table and all retained events. " +
+					"Set it to false and apply that change before destroying or replacing the source. Defaults to true.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(sourceDeletionProtectionDefault),
+			},
+			"deletion_protection_ingest_window_seconds": schema.Int32Attribute{
+				Description: "Also refuse deleting the source while its recent events include one ingested within this many seconds, " +
+					"even when deletion_protection is false.",
+				Optional: true,
+				Validators: []validator.Int32{
+					int32validator.AtLeast(1),
+				},
+			},
+			"drop_lql_filters": schema.StringAttribute{
+				Description: "LQL filters of events to drop at ingest, such as m.level:debug.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
 			"favorite": schema.BoolAttribute{
 				Description: "Whether the source is marked as a favorite.",
 				Optional:    true,
@@ -136,38 +226,151 @@ func (r *SourceResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				Description: "Timestamp of when the source was created.",
 				Computed:    true,
 			},
-			"metrics": schema.StringAttribute{
-				Description: "Metrics for the source, as a JSON string.",
+			"lock_schema": schema.BoolAttribute{
+				Description: "Whether to reject events that would add fields to the source schema.",
 				Optional:    true,
 				Computed:    true,
-				CustomType:  jsontypes.NormalizedType{},
+				Default:     booldefault.StaticBool(false),
+			},
+			"metrics": schema.SingleNestedAttribute{
+				Description: "Ingest metrics reported by the server. They change constantly and are only refreshed, never sent.",
+				Computed:    true,
+				Attributes: map[string]schema.Attribute{
+					"avg": schema.Float64Attribute{
+						Description: "Average number of events ingested per second.",
+						Computed:    true,
+					},
+					"buffer": schema.Int64Attribute{
+						Description: "Number of events waiting in the ingest buffer.",
+						Computed:    true,
+					},
+					"inserts": schema.Int64Attribute{
+						Description: "Total number of events inserted.",
+						Computed:    true,
+					},
+					"max": schema.Int64Attribute{
+						Description: "Highest number of events ingested per second.",
+						Computed:    true,
+					},
+					"rate": schema.Int64Attribute{
+						Description: "Current number of events ingested per second.",
+						Computed:    true,
+					},
+					"recent": schema.Int64Attribute{
+						Description: "Number of recent events kept for the source.",
+						Computed:    true,
+					},
+				},
 			},
-			"notifications": schema.ObjectAttribute{
-				Description:    "Notification settings for the source.",
-				Optional:       true,
-				Computed:       true,
-				AttributeTypes: NotificationModel{}.AttributeTypes(),
+			"notifications": schema.SingleNestedAttribute{
+				Description: "Notification settings for the source. Fields left out fall back to their defaults rather than being cleared.",
+				Optional:    true,
+				Computed:    true,
+				Attributes: map[string]schema.Attribute{
+					"other_email_notifications": schema.StringAttribute{
+						Description: "Comma-separated email addresses, outside of the team, to notify of new events.",
+						Optional:    true,
+					},
+					"team_user_ids_for_email": schema.SetAttribute{
+						Description: "IDs of the team users to notify of new events by email.",
+						Optional:    true,
+						Computed:    true,
+						ElementType: types.StringType,
+						Default:     setdefault.StaticValue(types.SetValueMust(types.StringType, []attr.Value{})),
+					},
+					"team_user_ids_for_schema_updates": schema.SetAttribute{
+						Description: "IDs of the team users to notify of schema updates.",
+						Optional:    true,
+						Computed:    true,
+						ElementType: types.StringType,
+						Default:     setdefault.StaticValue(types.SetValueMust(types.StringType, []attr.Value{})),
+					},
+					"team_user_ids_for_sms": schema.SetAttribute{
+						Description: "IDs of the team users to notify of new events by SMS.",
+						Optional:    true,
+						Computed:    true,
+						ElementType: types.StringType,
+						Default:     setdefault.StaticValue(types.SetValueMust(types.StringType, []attr.Value{})),
+					},
+					"user_email_notifications": schema.BoolAttribute{
+						Description: "Whether to notify the account owner of new events by email. Defaults to false.",
+						Optional:    true,
+						Computed:    true,
+						Default:     booldefault.StaticBool(false),
+					},
+					"user_schema_update_notifications": schema.BoolAttribute{
+						Description: "Whether to notify the account owner of schema updates. Defaults to true.",
+						Optional:    true,
+						Computed:    true,
+						Default:     booldefault.StaticBool(true),
+					},
+					"user_text_notifications": schema.BoolAttribute{
+						Description: "Whether to notify the account owner of new events by SMS. Defaults to false.",
+						Optional:    true,
+						Computed:    true,
+						Default:     booldefault.StaticBool(false),
+					},
+				},
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"public_token": schema.StringAttribute{
 				Description: "Public token for the source.",
 				Computed:    true,
 				Sensitive:   true,
 			},
+			"retention_days": schema.Int32Attribute{
+				Description: "Number of days to keep events for. Cannot be combined with bigquery_table_ttl.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.Int32{
+					int32validator.AtLeast(1),
+					int32validator.ConflictsWith(path.MatchRoot("bigquery_table_ttl")),
+				},
+			},
 			"slack_hook_url": schema.StringAttribute{
 				Description: "Slack webhook URL for notifications.",
 				Optional:    true,
 				Computed:    true,
 				Sensitive:   true,
 			},
+			"suggested_keys": schema.SetAttribute{
+				Description: "Field paths suggested when querying the source. A trailing ! marks a key as required.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.RegexMatches(sourceSuggestedKeyPattern, "must be a field path, optionally followed by !")),
+				},
+			},
 			"token": schema.StringAttribute{
-				Description: "Private token for the source.",
+				Description: "Private token for the source, used to ingest events.",
 				Computed:    true,
 				Sensitive:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"transform_copy_fields": schema.ListAttribute{
+				Description: "Copy rules applied to events at ingest, in order, as from:to pairs of field paths.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(stringvalidator.RegexMatches(sourceCopyFieldPattern, "must be a from:to pair of field paths")),
+				},
 			},
 			"updated_at": schema.StringAttribute{
 				Description: "Timestamp of when the source was last updated.",
 				Computed:    true,
 			},
+			"validate_schema": schema.BoolAttribute{
+				Description: "Whether to reject events whose field types conflict with the source schema.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
 			"webhook_notification_url": schema.StringAttribute{
 				Description: "Webhook URL for notifications.",
 				Optional:    true,
@@ -175,25 +378,109 @@ func (r *SourceResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				Sensitive:   true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *SourceResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return stateUpgraders(sourceStateUpgradeSteps...)
+}
+
+// MoveState accepts moved blocks from the logflare_source and
+// logflare_source_backend types of the legacy provider address.
+func (r *SourceResource) MoveState(_ context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		legacyStateMover("logflare_source", sourceStateUpgradeSteps),
+		sourceBackendStateMover(),
 	}
 }
 
+// sourceStateUpgradeSteps upgrade the state of logflare_source one schema
+// version at a time. The schema version is the number of steps; append a step
+// whenever an attribute changes type.
+var sourceStateUpgradeSteps = []stateUpgradeStep{
+	// Version 1 turned metrics from a JSON string into a computed object,
+	// which is filled in again by the next refresh.
+	func(state map[string]any) error {
+		delete(state, "metrics")
+		return nil
+	},
+	// Version 2 turned the notification lists into sets and gave the
+	// notification fields defaults.
+	upgradeSourceNotificationsV1,
+}
+
+// upgradeSourceNotificationsV1 drops duplicate team user IDs, which sets do
+// not allow, and fills in the defaults of fields that were null.
+func upgradeSourceNotificationsV1(state map[string]any) error {
+	notifications, ok := state["notifications"].(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	for _, name := range []string{"team_user_ids_for_email", "team_user_ids_for_schema_updates", "team_user_ids_for_sms"} {
+		ids, _ := notifications[name].([]any)
+		unique := []any{}
+		for _, id := range ids {
+			if !slices.Contains(unique, id) {
+				unique = append(unique, id)
+			}
+		}
+		notifications[name] = unique
+	}
+
+	for name, value := range map[string]bool{
+		"user_email_notifications":         false,
+		"user_schema_update_notifications": true,
+		"user_text_notifications":          false,
+	} {
+		if notifications[name] == nil {
+			notifications[name] = value
+		}
+	}
+
+	return nil
+}
+
+func (r *SourceResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = tokenIdentitySchema()
+}
+
 func (r *SourceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*api.ClientWithResponses)
+	data, ok := req.ProviderData.(*logflareProviderData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *api.ClientWithResponses, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *logflareProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.names = data.names
+	r.server = data.server
+}
+
+func (r *SourceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when the resource is being destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.server.validateConfig(ctx, "logflare_source", req.Config)...)
+
+	name, ownToken, checkName, diags := planNameCheck(ctx, req)
+	resp.Diagnostics.Append(diags...)
+	if checkName && r.client != nil {
+		resp.Diagnostics.Append(r.names.checkSourceName(ctx, r.client, name, ownToken)...)
+	}
 }
 
 func (r *SourceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -203,12 +490,27 @@ func (r *SourceResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	resp.Diagnostics.Append(createSource(ctx, &data, r.client)...)
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	desiredBackends := data.BackendTokens
+	resp.Diagnostics.Append(withTimeoutDiagnostics(ctx, createSource(ctx, &data, r.client), "create", "logflare_source", createTimeout)...)
+	r.names.forget("source")
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The source exists even when attaching backends fails, so it is saved
+	// either way and tainted by the error.
+	resp.Diagnostics.Append(withTimeoutDiagnostics(ctx, syncSourceBackends(ctx, &data, desiredBackends, r.client), "create", "logflare_source", createTimeout)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, tokenIdentityModel{Token: data.Token})...)
 }
 
 func createSource(ctx context.Context, data *SourceResourceModel, client *api.ClientWithResponses) diag.Diagnostics {
@@ -238,17 +540,33 @@ func (r *SourceResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	if data.Token.IsNull() {
 		resp.Diagnostics.AddWarning("Resource Read Ignored", "Source token is null, cannot read.")
 		return
 	}
 
-	resp.Diagnostics.Append(readSource(ctx, &data, r.client)...)
+	resp.Diagnostics.Append(withTimeoutDiagnostics(ctx, readSource(ctx, &data, r.client), "read", "logflare_source", readTimeout)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// The API does not know about deletion_protection. Imported sources and
+	// states written before the attribute existed get its default.
+	if data.DeletionProtection.IsNull() {
+		data.DeletionProtection = types.BoolValue(sourceDeletionProtectionDefault)
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, tokenIdentityModel{Token: data.Token})...)
 }
 
 func readSource(ctx context.Context, data *SourceResourceModel, client *api.ClientWithResponses) diag.Diagnostics {
@@ -274,12 +592,27 @@ func (r *SourceResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	resp.Diagnostics.Append(updateSource(ctx, &data, r.client)...)
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	desiredBackends := data.BackendTokens
+	resp.Diagnostics.Append(withTimeoutDiagnostics(ctx, updateSource(ctx, &data, r.client), "update", "logflare_source", updateTimeout)...)
+	r.names.forget("source")
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// The source is updated even when attaching or detaching backends fails,
+	// so it is saved either way with the attachments read back.
+	resp.Diagnostics.Append(withTimeoutDiagnostics(ctx, syncSourceBackends(ctx, &data, desiredBackends, r.client), "update", "logflare_source", updateTimeout)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, tokenIdentityModel{Token: data.Token})...)
 }
 
 func updateSource(ctx context.Context, data *SourceResourceModel, client *api.ClientWithResponses) diag.Diagnostics {
@@ -309,11 +642,34 @@ func (r *SourceResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	if data.Token.IsNull() {
 		return
 	}
 
-	resp.Diagnostics.Append(deleteSource(ctx, &data, r.client)...)
+	if deletionProtected(data.DeletionProtection, sourceDeletionProtectionDefault) {
+		resp.Diagnostics.Append(deletionProtectionDiagnostic("logflare_source", data.Name.ValueString()))
+		return
+	}
+
+	if !data.DeletionProtectionIngestWindowSeconds.IsNull() {
+		window := time.Duration(data.DeletionProtectionIngestWindowSeconds.ValueInt32()) * time.Second
+		resp.Diagnostics.Append(withTimeoutDiagnostics(ctx, checkSourceRecentEvents(ctx, r.client, &data, window, time.Now()), "delete", "logflare_source", deleteTimeout)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(withTimeoutDiagnostics(ctx, deleteSource(ctx, &data, r.client), "delete", "logflare_source", deleteTimeout)...)
+	r.names.forget("source")
 }
 
 func deleteSource(ctx context.Context, data *SourceResourceModel, client *api.ClientWithResponses) diag.Diagnostics {
@@ -331,6 +687,11 @@ func deleteSource(ctx context.Context, data *SourceResourceModel, client *api.Cl
 	return nil
 }
 
+func (r *SourceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("token"), path.Root("token"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("deletion_protection"), sourceDeletionProtectionDefault)...)
+}
+
 func sourceSchemaToModel(ctx context.Context, result *api.Source, data *SourceResourceModel) diag.Diagnostics {
 	data.Id = types.Int64Value(int64(*result.Id))
 	data.Name = types.StringValue(result.Name)
@@ -345,6 +706,38 @@ func sourceSchemaToModel(ctx context.Context, result *api.Source, data *SourceRe
 	data.SlackHookUrl = types.StringPointerValue(result.SlackHookUrl)
 	data.Token = types.StringPointerValue(result.Token)
 	data.WebhookNotificationUrl = types.StringPointerValue(result.WebhookNotificationUrl)
+	data.DropLqlFilters = types.StringPointerValue(result.DropLqlString)
+	if result.DropLqlString != nil && *result.DropLqlString == "" {
+		data.DropLqlFilters = types.StringNull()
+	}
+	data.LockSchema = types.BoolPointerValue(result.LockSchema)
+
+	// Attachments are only read back when they are managed.
+	if data.BackendTokens.IsNull() {
+		data.BackendTokens = types.SetNull(types.StringType)
+	} else {
+		var diags diag.Diagnostics
+		data.BackendTokens, diags = types.SetValueFrom(ctx, types.StringType, sourceBackendTokens(result))
+		if diags.HasError() {
+			return diags
+		}
+	}
+	data.RetentionDays = types.Int32PointerValue(intPtrToInt32Ptr(result.RetentionDays))
+	data.ValidateSchema = types.BoolPointerValue(result.ValidateSchema)
+
+	var diags diag.Diagnostics
+	data.BigqueryClusteringFields, diags = splitListValue(ctx, result.BigqueryClusteringFields, ",")
+	if diags.HasError() {
+		return diags
+	}
+	data.TransformCopyFields, diags = splitListValue(ctx, result.TransformCopyFields, "\n")
+	if diags.HasError() {
+		return diags
+	}
+	data.SuggestedKeys, diags = splitSetValue(ctx, result.SuggestedKeys, ",")
+	if diags.HasError() {
+		return diags
+	}
 
 	if result.InsertedAt == nil {
 		data.InsertedAt = types.StringNull()
@@ -359,17 +752,24 @@ func sourceSchemaToModel(ctx context.Context, result *api.Source, data *SourceRe
 	}
 
 	if result.Metrics != nil {
-		value, err := json.Marshal(result.Metrics)
-		if err != nil {
-			return diag.Diagnostics{diag.NewErrorDiagnostic("Can't encode 'metrics' field", err.Error())}
+		var diags diag.Diagnostics
+		data.Metrics, diags = types.ObjectValueFrom(ctx, SourceMetricsModel{}.AttributeTypes(), SourceMetricsModel{
+			Avg:     types.Float64PointerValue(result.Metrics.Avg),
+			Buffer:  types.Int64PointerValue(intPtrToInt64Ptr(result.Metrics.Buffer)),
+			Inserts: types.Int64PointerValue(intPtrToInt64Ptr(result.Metrics.Inserts)),
+			Max:     types.Int64PointerValue(intPtrToInt64Ptr(result.Metrics.Max)),
+			Rate:    types.Int64PointerValue(intPtrToInt64Ptr(result.Metrics.Rate)),
+			Recent:  types.Int64PointerValue(intPtrToInt64Ptr(result.Metrics.Recent)),
+		})
+		if diags.HasError() {
+			return diags
 		}
-		data.Metrics = jsontypes.NewNormalizedValue(string(value))
 	} else {
-		data.Metrics = jsontypes.NewNormalizedValue("{}")
+		data.Metrics = types.ObjectNull(SourceMetricsModel{}.AttributeTypes())
 	}
 
 	if result.Notifications != nil {
-		var diags, listDiags diag.Diagnostics
+		var diags, setDiags diag.Diagnostics
 		var apiNotifications api.Notification
 
 		b, err := json.Marshal(result.Notifications)
@@ -389,31 +789,31 @@ func sourceSchemaToModel(ctx context.Context, result *api.Source, data *SourceRe
 		}
 
 		if apiNotifications.TeamUserIdsForEmail != nil {
-			model.TeamUserIdsForEmail, diags = types.ListValueFrom(ctx, types.StringType, *apiNotifications.TeamUserIdsForEmail)
-			listDiags.Append(diags...)
+			model.TeamUserIdsForEmail, diags = types.SetValueFrom(ctx, types.StringType, *apiNotifications.TeamUserIdsForEmail)
+			setDiags.Append(diags...)
 		} else {
-			model.TeamUserIdsForEmail, diags = types.ListValue(types.StringType, nil)
-			listDiags.Append(diags...)
+			model.TeamUserIdsForEmail, diags = types.SetValue(types.StringType, nil)
+			setDiags.Append(diags...)
 		}
 
 		if apiNotifications.TeamUserIdsForSchemaUpdates != nil {
-			model.TeamUserIdsForSchemaUpdates, diags = types.ListValueFrom(ctx, types.StringType, *apiNotifications.TeamUserIdsForSchemaUpdates)
-			listDiags.Append(diags...)
+			model.TeamUserIdsForSchemaUpdates, diags = types.SetValueFrom(ctx, types.StringType, *apiNotifications.TeamUserIdsForSchemaUpdates)
+			setDiags.Append(diags...)
 		} else {
-			model.TeamUserIdsForSchemaUpdates, diags = types.ListValue(types.StringType, nil)
-			listDiags.Append(diags...)
+			model.TeamUserIdsForSchemaUpdates, diags = types.SetValue(types.StringType, nil)
+			setDiags.Append(diags...)
 		}
 
 		if apiNotifications.TeamUserIdsForSms != nil {
-			model.TeamUserIdsForSms, diags = types.ListValueFrom(ctx, types.StringType, *apiNotifications.TeamUserIdsForSms)
-			listDiags.Append(diags...)
+			model.TeamUserIdsForSms, diags = types.SetValueFrom(ctx, types.StringType, *apiNotifications.TeamUserIdsForSms)
+			setDiags.Append(diags...)
 		} else {
-			model.TeamUserIdsForSms, diags = types.ListValue(types.StringType, nil)
-			listDiags.Append(diags...)
+			model.TeamUserIdsForSms, diags = types.SetValue(types.StringType, nil)
+			setDiags.Append(diags...)
 		}
 
-		if listDiags.HasError() {
-			return listDiags
+		if setDiags.HasError() {
+			return setDiags
 		}
 
 		data.Notifications, diags = types.ObjectValueFrom(ctx, NotificationModel{}.AttributeTypes(), &model)
@@ -429,8 +829,6 @@ func sourceSchemaToModel(ctx context.Context, result *api.Source, data *SourceRe
 }
 
 func sourceModelToApiSchema(ctx context.Context, data *SourceResourceModel) (api.Source, diag.Diagnostics) {
-	var metrics *map[string]any
-	data.Metrics.Unmarshal(&metrics)
 	var diags, modelDiags diag.Diagnostics
 
 	body := api.Source{
@@ -441,12 +839,31 @@ func sourceModelToApiSchema(ctx context.Context, data *SourceResourceModel) (api
 		CustomEventMessageKeys:      data.CustomEventMessageKeys.ValueStringPointer(),
 		DefaultIngestBackendEnabled: data.DefaultIngestBackendEnabled.ValueBoolPointer(),
 		Favorite:                    data.Favorite.ValueBoolPointer(),
-		Metrics:                     metrics,
+		LockSchema:                  data.LockSchema.ValueBoolPointer(),
 		SlackHookUrl:                data.SlackHookUrl.ValueStringPointer(),
 		Token:                       data.Token.ValueStringPointer(),
+		ValidateSchema:              data.ValidateSchema.ValueBoolPointer(),
 		WebhookNotificationUrl:      data.WebhookNotificationUrl.ValueStringPointer(),
 	}
 
+	// Null is left out of the request, so an empty string clears these fields.
+	dropLqlString := data.DropLqlFilters.ValueString()
+	body.DropLqlString = &dropLqlString
+
+	if !data.RetentionDays.IsUnknown() {
+		body.RetentionDays = int32PtrToIntPtr(data.RetentionDays.ValueInt32Pointer())
+	}
+
+	body.BigqueryClusteringFields, diags = joinValue(ctx, data.BigqueryClusteringFields, ",")
+	modelDiags.Append(diags...)
+	body.TransformCopyFields, diags = joinValue(ctx, data.TransformCopyFields, "\n")
+	modelDiags.Append(diags...)
+	body.SuggestedKeys, diags = joinValue(ctx, data.SuggestedKeys, ",")
+	modelDiags.Append(diags...)
+	if modelDiags.HasError() {
+		return body, modelDiags
+	}
+
 	if !data.Notifications.IsNull() && !data.Notifications.IsUnknown() {
 		var model NotificationModel
 		diags = data.Notifications.As(ctx, &model, basetypes.ObjectAsOptions{})
@@ -502,3 +919,77 @@ func sourceModelToApiSchema(ctx context.Context, data *SourceResourceModel) (api
 
 	return body, diags
 }
+
+var (
+	// sourceFieldPathPattern matches a dotted path to an event field.
+	sourceFieldPathPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)*$`)
+	// sourceSuggestedKeyPattern matches a field path with an optional
+	// trailing ! that makes the key required.
+	sourceSuggestedKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)*!?$`)
+	// sourceCopyFieldPattern matches a copy rule of two field paths.
+	sourceCopyFieldPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)*:[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)*$`)
+)
+
+// stringElements is implemented by list and set values of strings.
+type stringElements interface {
+	IsNull() bool
+	IsUnknown() bool
+	ElementsAs(ctx context.Context, target any, allowUnhandled bool) diag.Diagnostics
+}
+
+// joinValue joins the elements of a list or set into the separated string
+// the API stores. A null value is joined into an empty string, which clears
+// the field.
+func joinValue(ctx context.Context, value stringElements, sep string) (*string, diag.Diagnostics) {
+	if value.IsUnknown() {
+		return nil, nil
+	}
+
+	var elements []string
+	if !value.IsNull() {
+		if diags := value.ElementsAs(ctx, &elements, false); diags.HasError() {
+			return nil, diags
+		}
+	}
+
+	joined := strings.Join(elements, sep)
+	return &joined, nil
+}
+
+// splitStrings splits a separated string from the API into its elements.
+func splitStrings(value *string, sep string) []string {
+	if value == nil {
+		return nil
+	}
+
+	var elements []string
+	for _, element := range strings.Split(*value, sep) {
+		if element = strings.TrimSpace(element); element != "" {
+			elements = append(elements, element)
+		}
+	}
+
+	return elements
+}
+
+// splitListValue splits a separated string into a list, which is null when
+// the string has no elements.
+func splitListValue(ctx context.Context, value *string, sep string) (types.List, diag.Diagnostics) {
+	elements := splitStrings(value, sep)
+	if len(elements) == 0 {
+		return types.ListNull(types.StringType), nil
+	}
+
+	return types.ListValueFrom(ctx, types.StringType, elements)
+}
+
+// splitSetValue splits a separated string into a set, which is null when the
+// string has no elements.
+func splitSetValue(ctx context.Context, value *string, sep string) (types.Set, diag.Diagnostics) {
+	elements := splitStrings(value, sep)
+	if len(elements) == 0 {
+		return types.SetNull(types.StringType), nil
+	}
+
+	return types.SetValueFrom(ctx, types.StringType, elements)
+}
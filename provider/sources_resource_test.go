@@ -4,9 +4,18 @@
 package provider
 
 import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"slices"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/supabase/terraform-provider-supabase-analytics/internal/pkg/api"
 )
 
 func TestAccSourcesResource(t *testing.T) {
@@ -21,14 +30,118 @@ func TestAccSourcesResource(t *testing.T) {
 					resource.TestCheckResourceAttrSet("logflare_source.source_test", "id"),
 					resource.TestCheckResourceAttrSet("logflare_source.source_test", "token"),
 				),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentityValueMatchesState("logflare_source.source_test", tfjsonpath.New("token")),
+				},
+			},
+			// Import by identity. The import runs in its own working directory,
+			// with a configuration that leaves deletion_protection at the
+			// default the import fills in.
+			{
+				Config:          providerConfig + testAccSourcesResourceImportConfig,
+				ResourceName:    "logflare_source.source_test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
 			},
 		},
 	})
 }
 
 const testAccSourcesResourceConfig = `
+resource "logflare_source" "source_test" {
+	name = "my-cool-source"
+    favorite = true
+	deletion_protection = false
+}
+`
+
+const testAccSourcesResourceImportConfig = `
 resource "logflare_source" "source_test" {
 	name = "my-cool-source"
     favorite = true
 }
 `
+
+func TestSourceAdvancedSettingsRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	id := 1
+	clustering, copyFields, keys, dropLql := "metadata.project, id", "metadata.a:b\nc:metadata.d\n", "m.user_id!,m.level", ""
+	result := api.Source{
+		BigqueryClusteringFields: &clustering,
+		DropLqlString:            &dropLql,
+		Id:                       &id,
+		Name:                     "my-source",
+		SuggestedKeys:            &keys,
+		TransformCopyFields:      &copyFields,
+	}
+
+	var data SourceResourceModel
+	if diags := sourceSchemaToModel(ctx, &result, &data); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	var clusteringFields []string
+	data.BigqueryClusteringFields.ElementsAs(ctx, &clusteringFields, false)
+	if !slices.Equal(clusteringFields, []string{"metadata.project", "id"}) {
+		t.Errorf("unexpected clustering fields %q", clusteringFields)
+	}
+	if len(data.TransformCopyFields.Elements()) != 2 || len(data.SuggestedKeys.Elements()) != 2 {
+		t.Errorf("unexpected copy fields %s or suggested keys %s", data.TransformCopyFields, data.SuggestedKeys)
+	}
+	if !data.DropLqlFilters.IsNull() {
+		t.Errorf("expected empty drop filters to be null, got %s", data.DropLqlFilters)
+	}
+
+	data.Notifications = types.ObjectNull(NotificationModel{}.AttributeTypes())
+	data.SuggestedKeys = types.SetNull(types.StringType)
+	body, diags := sourceModelToApiSchema(ctx, &data)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if *body.BigqueryClusteringFields != "metadata.project,id" || *body.TransformCopyFields != "metadata.a:b\nc:metadata.d" {
+		t.Errorf("unexpected body %+v", body)
+	}
+	if body.SuggestedKeys == nil || *body.SuggestedKeys != "" {
+		t.Errorf("expected null suggested keys to be cleared, got %v", body.SuggestedKeys)
+	}
+}
+
+func TestSourceSettingPatterns(t *testing.T) {
+	tests := []struct {
+		pattern *regexp.Regexp
+		value   string
+		want    bool
+	}{
+		{sourceFieldPathPattern, "metadata.user_id", true},
+		{sourceFieldPathPattern, "metadata..user_id", false},
+		{sourceSuggestedKeyPattern, "m.user_id!", true},
+		{sourceSuggestedKeyPattern, "m.user_id!!", false},
+		{sourceCopyFieldPattern, "metadata.a:b", true},
+		{sourceCopyFieldPattern, "metadata.a", false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.pattern.MatchString(tt.value); got != tt.want {
+			t.Errorf("%s.MatchString(%q) = %v, want %v", tt.pattern, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestSourceMetricsFractionalAverage(t *testing.T) {
+	ctx := context.Background()
+	var result api.Source
+	if err := json.Unmarshal([]byte(`{"id": 1, "name": "my-source", "metrics": {"avg": 2.5, "max": 4}}`), &result); err != nil {
+		t.Fatalf("unable to decode source: %s", err)
+	}
+
+	var data SourceResourceModel
+	if diags := sourceSchemaToModel(ctx, &result, &data); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	var metrics SourceMetricsModel
+	data.Metrics.As(ctx, &metrics, basetypes.ObjectAsOptions{})
+	if metrics.Avg.ValueFloat64() != 2.5 || metrics.Max.ValueInt64() != 4 {
+		t.Errorf("unexpected metrics %+v", metrics)
+	}
+}
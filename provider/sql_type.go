@@ -0,0 +1,211 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var (
+	_ basetypes.StringTypable                    = SQLQueryType{}
+	_ basetypes.StringValuableWithSemanticEquals = SQLQuery{}
+)
+
+// SQLQueryType is a string type for SQL queries whose values are considered
+// equal when they only differ in whitespace, comments or the casing of
+// keywords and function names.
+type SQLQueryType struct {
+	basetypes.StringType
+}
+
+func (t SQLQueryType) String() string {
+	return "SQLQueryType"
+}
+
+func (t SQLQueryType) ValueType(_ context.Context) attr.Value {
+	return SQLQuery{}
+}
+
+func (t SQLQueryType) Equal(o attr.Type) bool {
+	other, ok := o.(SQLQueryType)
+	if !ok {
+		return false
+	}
+
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t SQLQueryType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return SQLQuery{StringValue: in}, nil
+}
+
+func (t SQLQueryType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	stringValuable, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+
+	return stringValuable, nil
+}
+
+// SQLQuery is the value of a SQLQueryType attribute.
+type SQLQuery struct {
+	basetypes.StringValue
+}
+
+// NewSQLQueryValue returns a known SQLQuery holding query.
+func NewSQLQueryValue(query string) SQLQuery {
+	return SQLQuery{StringValue: basetypes.NewStringValue(query)}
+}
+
+func (v SQLQuery) Type(_ context.Context) attr.Type {
+	return SQLQueryType{}
+}
+
+func (v SQLQuery) Equal(o attr.Value) bool {
+	other, ok := o.(SQLQuery)
+	if !ok {
+		return false
+	}
+
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals reports whether both queries consist of the same
+// tokens, so that reformatting a query does not produce a diff.
+func (v SQLQuery) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(SQLQuery)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			"An unexpected value type was received while performing semantic equality checks. "+
+				"Please report this to the provider developers.\n\n"+
+				"Expected Value Type: "+fmt.Sprintf("%T", v)+"\n"+
+				"Got Value Type: "+fmt.Sprintf("%T", newValuable),
+		)
+		return false, diags
+	}
+
+	return slices.Equal(sqlTokens(v.ValueString()), sqlTokens(newValue.ValueString())), diags
+}
+
+// sqlKeywords are compared case-insensitively, as are function names. Other
+// identifiers are not, as table names are case-sensitive on some backends.
+var sqlKeywords = map[string]bool{
+	"all": true, "and": true, "any": true, "array": true, "as": true, "asc": true,
+	"between": true, "by": true, "case": true, "cast": true, "cross": true, "current": true,
+	"desc": true, "distinct": true, "else": true, "end": true, "except": true, "exists": true,
+	"false": true, "filter": true, "first": true, "following": true, "from": true, "full": true,
+	"group": true, "having": true, "ilike": true, "in": true, "inner": true, "intersect": true,
+	"interval": true, "is": true, "join": true, "last": true, "lateral": true, "left": true,
+	"like": true, "limit": true, "natural": true, "not": true, "null": true, "nulls": true,
+	"offset": true, "on": true, "or": true, "order": true, "outer": true, "over": true,
+	"partition": true, "preceding": true, "qualify": true, "range": true, "recursive": true,
+	"right": true, "row": true, "rows": true, "select": true, "some": true, "struct": true,
+	"then": true, "true": true, "unbounded": true, "union": true, "unnest": true, "using": true,
+	"when": true, "where": true, "window": true, "with": true,
+}
+
+// sqlTokens splits query into tokens, dropping whitespace and comments and
+// lowercasing keywords and the names of called functions. String literals
+// and quoted identifiers are kept verbatim.
+func sqlTokens(query string) []string {
+	var tokens []string
+	runes := []rune(query)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			i += 2
+			for i < len(runes) && (runes[i] != '*' || i+1 >= len(runes) || runes[i+1] != '/') {
+				i++
+			}
+			i += 2
+
+		case r == '\'' || r == '"' || r == '`':
+			start := i
+			i++
+			for i < len(runes) {
+				if runes[i] == '\\' {
+					i += 2
+					continue
+				}
+				if runes[i] == r {
+					// A doubled quote is an escaped quote.
+					if i+1 < len(runes) && runes[i+1] == r {
+						i += 2
+						continue
+					}
+					break
+				}
+				i++
+			}
+			i = min(i+1, len(runes))
+			tokens = append(tokens, string(runes[start:i]))
+
+		case isSQLWordRune(r):
+			start := i
+			for i < len(runes) && isSQLWordRune(runes[i]) {
+				i++
+			}
+			word := string(runes[start:i])
+			if lower := strings.ToLower(word); sqlKeywords[lower] || isSQLFunctionCall(runes[i:]) {
+				word = lower
+			}
+			tokens = append(tokens, word)
+
+		default:
+			tokens = append(tokens, string(r))
+			i++
+		}
+	}
+
+	return tokens
+}
+
+// isSQLFunctionCall reports whether rest, the remainder of the query after a
+// word, opens an argument list.
+func isSQLFunctionCall(rest []rune) bool {
+	for _, r := range rest {
+		if !unicode.IsSpace(r) {
+			return r == '('
+		}
+	}
+	return false
+}
+
+func isSQLWordRune(r rune) bool {
+	return r == '_' || r == '$' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,80 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSQLQueryStringSemanticEquals(t *testing.T) {
+	testCases := map[string]struct {
+		current   string
+		new       string
+		wantEqual bool
+	}{
+		"identical": {
+			current:   "select id from my_source",
+			new:       "select id from my_source",
+			wantEqual: true,
+		},
+		"whitespace": {
+			current:   "select id, event_message\nfrom my_source\n  where id > 1",
+			new:       "select id,event_message from my_source where id>1",
+			wantEqual: true,
+		},
+		"keyword casing": {
+			current:   "SELECT id FROM my_source WHERE id IS NOT NULL",
+			new:       "select id from my_source where id is not null",
+			wantEqual: true,
+		},
+		"comments": {
+			current:   "-- recent events\nselect id /* primary key */ from my_source",
+			new:       "select id from my_source",
+			wantEqual: true,
+		},
+		"function casing": {
+			current:   "select COUNT(*), Date_Trunc (timestamp, day) from my_source",
+			new:       "select count(*), date_trunc(timestamp, day) from my_source",
+			wantEqual: true,
+		},
+		"identifier casing": {
+			current: "select id from My_Source",
+			new:     "select id from my_source",
+		},
+		"string literal": {
+			current: "select id from my_source where name = 'Foo  Bar'",
+			new:     "select id from my_source where name = 'foo bar'",
+		},
+		"comment marker in string literal": {
+			current: "select id from my_source where name = '-- not a comment'",
+			new:     "select id from my_source where name = ''",
+		},
+		"escaped quote": {
+			current:   "select 'it''s' from my_source",
+			new:       "SELECT 'it''s'\nFROM my_source",
+			wantEqual: true,
+		},
+		"changed query": {
+			current: "select id from my_source",
+			new:     "select id, event_message from my_source",
+		},
+		"split word": {
+			current: "select id from my_source",
+			new:     "select id from my _source",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			equal, diags := NewSQLQueryValue(testCase.current).StringSemanticEquals(context.Background(), NewSQLQueryValue(testCase.new))
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if equal != testCase.wantEqual {
+				t.Errorf("got %t, want %t", equal, testCase.wantEqual)
+			}
+		})
+	}
+}
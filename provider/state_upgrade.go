@@ -0,0 +1,131 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// stateUpgradeStep rewrites the JSON state of a resource from one schema
+// version to the next. Attributes it leaves out are read back as null, and
+// attributes the current schema no longer has are dropped after the last step.
+type stateUpgradeStep func(state map[string]any) error
+
+// stateUpgraders returns upgraders from every prior schema version to the
+// current one, which is len(steps). steps[i] upgrades version i to i+1, and
+// older states go through all later steps in order.
+func stateUpgraders(steps ...stateUpgradeStep) map[int64]resource.StateUpgrader {
+	upgraders := make(map[int64]resource.StateUpgrader, len(steps))
+	for version := range steps {
+		upgraders[int64(version)] = resource.StateUpgrader{
+			StateUpgrader: upgradeJSONState(version, steps[version:]),
+		}
+	}
+
+	return upgraders
+}
+
+func upgradeJSONState(version int, steps []stateUpgradeStep) func(context.Context, resource.UpgradeStateRequest, *resource.UpgradeStateResponse) {
+	return func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+		if req.RawState == nil || req.RawState.JSON == nil {
+			resp.Diagnostics.AddError(
+				"Unable to Upgrade Resource State",
+				fmt.Sprintf("The state of schema version %d has no JSON data. Please report this issue to the provider developers.", version),
+			)
+			return
+		}
+
+		state, diags := applyStateUpgradeSteps(req.RawState.JSON, version, steps)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		dropUnknownStateKeys(state, resp.State.Schema.Type().TerraformType(ctx))
+
+		upgraded, err := json.Marshal(state)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to Upgrade Resource State", err.Error())
+			return
+		}
+
+		resp.DynamicValue = &tfprotov6.DynamicValue{JSON: upgraded}
+	}
+}
+
+// applyStateUpgradeSteps decodes the JSON state of schema version and runs it
+// through steps, which must start at that version.
+func applyStateUpgradeSteps(raw []byte, version int, steps []stateUpgradeStep) (map[string]any, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	// Decode numbers as json.Number so large identifiers keep their precision.
+	var state map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&state); err != nil {
+		diags.AddError(
+			"Unable to Upgrade Resource State",
+			fmt.Sprintf("Unable to decode the state of schema version %d: %s", version, err),
+		)
+		return nil, diags
+	}
+
+	for i, step := range steps {
+		if err := step(state); err != nil {
+			diags.AddError(
+				"Unable to Upgrade Resource State",
+				fmt.Sprintf("Unable to upgrade the state from schema version %d to %d: %s", version+i, version+i+1, err),
+			)
+			return nil, diags
+		}
+	}
+
+	return state, diags
+}
+
+// dropUnknownStateKeys removes the keys of objects in the JSON state value
+// that typ has no attribute for, at any depth, so that attributes dropped from
+// nested objects do not fail decoding.
+func dropUnknownStateKeys(value any, typ tftypes.Type) {
+	switch typ := typ.(type) {
+	case tftypes.Object:
+		object, ok := value.(map[string]any)
+		if !ok {
+			return
+		}
+		for name, attribute := range object {
+			attributeType, ok := typ.AttributeTypes[name]
+			if !ok {
+				delete(object, name)
+				continue
+			}
+			dropUnknownStateKeys(attribute, attributeType)
+		}
+	case tftypes.List:
+		dropUnknownStateElementKeys(value, typ.ElementType)
+	case tftypes.Set:
+		dropUnknownStateElementKeys(value, typ.ElementType)
+	case tftypes.Map:
+		if elements, ok := value.(map[string]any); ok {
+			for _, element := range elements {
+				dropUnknownStateKeys(element, typ.ElementType)
+			}
+		}
+	}
+}
+
+func dropUnknownStateElementKeys(value any, elementType tftypes.Type) {
+	if elements, ok := value.([]any); ok {
+		for _, element := range elements {
+			dropUnknownStateKeys(element, elementType)
+		}
+	}
+}
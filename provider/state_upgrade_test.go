@@ -0,0 +1,188 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// upgradeTestState reads the state fixture of version from testdata/state and
+// upgrades it the way the framework does: states of the current version are
+// decoded ignoring attributes the schema no longer has, older ones go through
+// the upgrader of their version.
+func upgradeTestState(t *testing.T, r resource.ResourceWithUpgradeState, version int64) tfsdk.State {
+	t.Helper()
+	ctx := context.Background()
+
+	var metadataResp resource.MetadataResponse
+	r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "logflare"}, &metadataResp)
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	fixture := fmt.Sprintf("%s_v%d.json", strings.TrimPrefix(metadataResp.TypeName, "logflare_"), version)
+	raw, err := os.ReadFile(filepath.Join("testdata", "state", fixture))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rawState := &tfprotov6.RawState{JSON: raw}
+	if version == schemaResp.Schema.Version {
+		value, err := rawState.UnmarshalWithOpts(schemaResp.Schema.Type().TerraformType(ctx), tfprotov6.UnmarshalOpts{
+			ValueFromJSONOpts: tftypes.ValueFromJSONOpts{IgnoreUndefinedAttributes: true},
+		})
+		if err != nil {
+			t.Fatalf("%s does not match the current schema: %s", fixture, err)
+		}
+		return tfsdk.State{Schema: schemaResp.Schema, Raw: value}
+	}
+
+	upgrader, ok := r.UpgradeState(ctx)[version]
+	if !ok {
+		t.Fatalf("no state upgrader for version %d", version)
+	}
+
+	resp := resource.UpgradeStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	upgrader.StateUpgrader(ctx, resource.UpgradeStateRequest{RawState: rawState}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	value, err := resp.DynamicValue.Unmarshal(schemaResp.Schema.Type().TerraformType(ctx))
+	if err != nil {
+		t.Fatalf("upgraded %s does not match the current schema: %s", fixture, err)
+	}
+
+	return tfsdk.State{Schema: schemaResp.Schema, Raw: value}
+}
+
+func TestResourceStateUpgraders(t *testing.T) {
+	ctx := context.Background()
+
+	for _, r := range []resource.ResourceWithUpgradeState{&SourceResource{}, &EndpointResource{}, &SourceSchemaSeedResource{}} {
+		var schemaResp resource.SchemaResponse
+		r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+		upgraders := r.UpgradeState(ctx)
+		if int64(len(upgraders)) != schemaResp.Schema.Version {
+			t.Errorf("%T has schema version %d but %d state upgraders", r, schemaResp.Schema.Version, len(upgraders))
+		}
+		for version := range schemaResp.Schema.Version {
+			if _, ok := upgraders[version]; !ok {
+				t.Errorf("%T has no state upgrader for version %d", r, version)
+			}
+		}
+	}
+}
+
+func TestSourceResourceUpgradeStateV0(t *testing.T) {
+	var data SourceResourceModel
+	if diags := upgradeTestState(t, &SourceResource{}, 0).Get(context.Background(), &data); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if !data.Metrics.IsNull() {
+		t.Errorf("expected metrics to be null until the next refresh, got %s", data.Metrics)
+	}
+	if data.Id.ValueInt64() != 9007199254740993 {
+		t.Errorf("id lost precision: %d", data.Id.ValueInt64())
+	}
+	if data.Token.ValueString() != "source-token" || data.ApiQuota.ValueInt32() != 25 {
+		t.Errorf("unexpected upgraded state %+v", data)
+	}
+
+	var notifications NotificationModel
+	if diags := data.Notifications.As(context.Background(), &notifications, basetypes.ObjectAsOptions{}); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if len(notifications.TeamUserIdsForEmail.Elements()) != 2 || notifications.TeamUserIdsForSms.IsNull() {
+		t.Errorf("unexpected upgraded notifications %+v", notifications)
+	}
+}
+
+func TestSourceResourceUpgradeStateV1(t *testing.T) {
+	var data SourceResourceModel
+	if diags := upgradeTestState(t, &SourceResource{}, 1).Get(context.Background(), &data); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	var notifications NotificationModel
+	if diags := data.Notifications.As(context.Background(), &notifications, basetypes.ObjectAsOptions{}); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if len(notifications.TeamUserIdsForEmail.Elements()) != 2 {
+		t.Errorf("expected duplicate team user IDs to be dropped, got %s", notifications.TeamUserIdsForEmail)
+	}
+	if notifications.TeamUserIdsForSchemaUpdates.IsNull() || len(notifications.TeamUserIdsForSchemaUpdates.Elements()) != 0 {
+		t.Errorf("expected an empty set, got %s", notifications.TeamUserIdsForSchemaUpdates)
+	}
+	if !notifications.UserEmailNotifications.ValueBool() || !notifications.UserSchemaUpdateNotifications.ValueBool() || notifications.UserTextNotifications.ValueBool() {
+		t.Errorf("unexpected notification flags %+v", notifications)
+	}
+	// The fixture still has token_rotation_trigger, which was removed
+	// without a version change.
+	if data.Metrics.IsNull() || data.Token.ValueString() != "source-token" {
+		t.Errorf("expected the attributes of version 1 to be kept, got %+v", data)
+	}
+}
+
+// Version 0 is still the current schema version, as endpointStateUpgradeSteps is empty,
+// so no upgrader runs and the fixture is only decoded against the schema.
+// Once a step is added, this becomes an upgrade test.
+func TestEndpointResourceStateV0MatchesCurrentSchema(t *testing.T) {
+	var data EndpointResourceModel
+	if diags := upgradeTestState(t, &EndpointResource{}, 0).Get(context.Background(), &data); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if data.Token.ValueString() != "endpoint-token" || data.Query.ValueString() != "select id from my_source" {
+		t.Errorf("unexpected state %+v", data)
+	}
+	if !data.Language.IsNull() || !data.Labels.IsNull() {
+		t.Errorf("expected attributes added later to be null, got %+v", data)
+	}
+}
+
+func TestSourceSchemaSeedResourceUpgradeStateV0(t *testing.T) {
+	var data SourceSchemaSeedResourceModel
+	if diags := upgradeTestState(t, &SourceSchemaSeedResource{}, 0).Get(context.Background(), &data); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if data.SourceToken.ValueString() != "source-token" || len(data.Events.Elements()) != 1 || len(data.ExpectedFields.Elements()) != 1 {
+		t.Errorf("unexpected upgraded state %+v", data)
+	}
+	if data.Schema.ValueString() != `{"metadata":{"user_id":"integer"}}` {
+		t.Errorf("expected the schema to be kept, got %s", data.Schema)
+	}
+	if !data.Timeouts.IsNull() {
+		t.Errorf("expected timeouts to stay unset, got %s", data.Timeouts)
+	}
+
+	// The step itself removes timeout_seconds, rather than the final pass
+	// that drops attributes unknown to the current schema.
+	raw, err := os.ReadFile(filepath.Join("testdata", "state", "source_schema_seed_v0.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	state, diags := applyStateUpgradeSteps(raw, 0, sourceSchemaSeedStateUpgradeSteps)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if _, ok := state["timeout_seconds"]; ok {
+		t.Errorf("expected timeout_seconds to be removed, got %v", state)
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Default timeouts of resource operations. Every operation is a handful of
+// API calls, so these mostly guard against a hanging server.
+const (
+	defaultCreateTimeout = 5 * time.Minute
+	defaultReadTimeout   = 2 * time.Minute
+	defaultUpdateTimeout = 5 * time.Minute
+	defaultDeleteTimeout = 5 * time.Minute
+)
+
+// timeoutsBlock returns the timeouts block shared by all resources.
+func timeoutsBlock(ctx context.Context) schema.Block {
+	return timeouts.Block(ctx, timeouts.Opts{
+		Create: true,
+		Read:   true,
+		Update: true,
+		Delete: true,
+	})
+}
+
+// nullTimeouts returns an unset timeouts block, for models that are not read
+// from a plan or state.
+func nullTimeouts() timeouts.Value {
+	return timeouts.Value{
+		Object: types.ObjectNull(map[string]attr.Type{
+			"create": types.StringType,
+			"read":   types.StringType,
+			"update": types.StringType,
+			"delete": types.StringType,
+		}),
+	}
+}
+
+// withTimeoutDiagnostics replaces failing diags with a diagnostic naming the
+// operation and resource type when ctx ran out of time, as the client errors
+// only say that a deadline was exceeded. Operations that succeeded just before
+// the deadline keep their diagnostics, so that their result is saved.
+func withTimeoutDiagnostics(ctx context.Context, diags diag.Diagnostics, operation string, resourceType string, timeout time.Duration) diag.Diagnostics {
+	if !diags.HasError() || !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return diags
+	}
+
+	return diag.Diagnostics{diag.NewErrorDiagnostic(
+		"Operation Timed Out",
+		fmt.Sprintf("Unable to %s %s within the %s timeout of %s. "+
+			"The Logflare API did not respond in time; retry the operation or raise timeouts.%s in the resource configuration.",
+			operation, resourceType, operation, timeout, operation),
+	)}
+}
@@ -0,0 +1,52 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func TestWithTimeoutDiagnostics(t *testing.T) {
+	clientDiags := diag.Diagnostics{diag.NewErrorDiagnostic("Client Error", "context deadline exceeded")}
+
+	diags := withTimeoutDiagnostics(context.Background(), clientDiags, "create", "logflare_source", time.Minute)
+	if !diags.Equal(clientDiags) {
+		t.Errorf("diagnostics should be unchanged without a deadline, got %v", diags)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+
+	diags = withTimeoutDiagnostics(ctx, clientDiags, "create", "logflare_source", time.Minute)
+	if len(diags) != 1 || diags[0].Summary() != "Operation Timed Out" {
+		t.Fatalf("expected a timeout diagnostic, got %v", diags)
+	}
+	for _, want := range []string{"create logflare_source", "1m0s", "timeouts.create"} {
+		if !strings.Contains(diags[0].Detail(), want) {
+			t.Errorf("timeout diagnostic does not mention %q: %s", want, diags[0].Detail())
+		}
+	}
+}
+
+func TestWithTimeoutDiagnosticsSuccessAfterDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+
+	warnings := diag.Diagnostics{diag.NewWarningDiagnostic("Query Not Validated", "unreachable")}
+	diags := withTimeoutDiagnostics(ctx, warnings, "create", "logflare_source", time.Minute)
+	if !diags.Equal(warnings) {
+		t.Errorf("diagnostics of a successful operation should be unchanged, got %v", diags)
+	}
+
+	if diags := withTimeoutDiagnostics(ctx, nil, "create", "logflare_source", time.Minute); len(diags) != 0 {
+		t.Errorf("expected no diagnostics for a successful operation, got %v", diags)
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// tlsConfigFromModel builds the TLS configuration for the Logflare API client
+// from the provider configuration. It returns nil when no TLS attribute is
+// set, so that the default transport settings are kept.
+func tlsConfigFromModel(config logflareProviderModel) (*tls.Config, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	for name, value := range map[string]types.String{
+		"ca_cert":     config.CACert,
+		"client_cert": config.ClientCert,
+		"client_key":  config.ClientKey,
+	} {
+		if value.IsUnknown() {
+			diags.AddAttributeError(
+				path.Root(name),
+				"Unknown TLS Setting",
+				"The provider cannot create the Logflare API client as there is an unknown configuration value for "+name+". "+
+					"Either target apply the source of the value first or set the value statically in the configuration.",
+			)
+		}
+	}
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	if config.CACert.IsNull() && config.ClientCert.IsNull() && config.ClientKey.IsNull() && !config.InsecureSkipVerify.ValueBool() {
+		return nil, diags
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: config.InsecureSkipVerify.ValueBool(),
+	}
+
+	if !config.CACert.IsNull() {
+		pem, err := readPEM(config.CACert)
+		if err == nil {
+			tlsConfig.RootCAs, err = certPoolWithPEM(pem)
+		}
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("ca_cert"),
+				"Invalid CA Certificate",
+				"The provider cannot use the configured CA certificate: "+err.Error(),
+			)
+		}
+	}
+
+	if config.ClientCert.IsNull() != config.ClientKey.IsNull() {
+		missing := "client_key"
+		if config.ClientCert.IsNull() {
+			missing = "client_cert"
+		}
+		diags.AddAttributeError(
+			path.Root(missing),
+			"Incomplete Client Certificate",
+			"Both client_cert and client_key must be set to authenticate with a client certificate.",
+		)
+	}
+
+	if !config.ClientCert.IsNull() && !config.ClientKey.IsNull() {
+		certPEM, err := readPEM(config.ClientCert)
+		if err != nil {
+			diags.AddAttributeError(path.Root("client_cert"), "Invalid Client Certificate", "The provider cannot read the client certificate: "+err.Error())
+		}
+		keyPEM, err := readPEM(config.ClientKey)
+		if err != nil {
+			diags.AddAttributeError(path.Root("client_key"), "Invalid Client Key", "The provider cannot read the client key: "+err.Error())
+		}
+
+		if !diags.HasError() {
+			cert, err := tls.X509KeyPair(certPEM, keyPEM)
+			if err != nil {
+				diags.AddAttributeError(
+					path.Root("client_cert"),
+					"Invalid Client Certificate",
+					"The client certificate and key cannot be used together: "+err.Error(),
+				)
+			} else {
+				tlsConfig.Certificates = []tls.Certificate{cert}
+			}
+		}
+	}
+
+	return tlsConfig, diags
+}
+
+// readPEM returns the PEM content of value, which holds either the PEM data
+// itself or the path to a file containing it.
+func readPEM(value types.String) ([]byte, error) {
+	s := value.ValueString()
+	if strings.Contains(s, "-----BEGIN ") {
+		return []byte(s), nil
+	}
+
+	return os.ReadFile(s)
+}
+
+func certPoolWithPEM(pem []byte) (*x509.CertPool, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, errors.New("no valid PEM-encoded certificates found")
+	}
+
+	return pool, nil
+}
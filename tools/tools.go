@@ -18,5 +18,6 @@ import (
 // to ensure the documentation is formatted properly.
 //go:generate terraform fmt -recursive ../examples/
 
-// Generate documentation.
+// Generate documentation. The provider is published as supabase/supabase-analytics,
+// while its resource types keep the logflare prefix that -provider-name refers to.
 //go:generate go run github.com/hashicorp/terraform-plugin-docs/cmd/tfplugindocs generate --provider-dir .. -provider-name logflare